@@ -0,0 +1,17 @@
+package card
+
+import "go_poker/i18n"
+
+const msgInvalidCard i18n.Message = "card.invalid_card"
+
+// ErrInvalidCard はカードの表記を読み込めなかったときのエラー
+var ErrInvalidCard = i18n.NewError(msgInvalidCard)
+
+func init() {
+	i18n.Register(i18n.Japanese, map[i18n.Message]string{
+		msgInvalidCard: "カードの表記が不正です: %s",
+	})
+	i18n.Register(i18n.English, map[i18n.Message]string{
+		msgInvalidCard: "Invalid card notation: %s",
+	})
+}
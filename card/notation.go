@@ -0,0 +1,87 @@
+package card
+
+import (
+	"strings"
+)
+
+// Symbol はハンド履歴で使う1文字の表記を返す。Tenは"T"になる
+func (cn CardNumber) Symbol() string {
+	switch cn {
+	case Ace:
+		return "A"
+	case Ten:
+		return "T"
+	case Jack:
+		return "J"
+	case Queen:
+		return "Q"
+	case King:
+		return "K"
+	default:
+		if cn >= Two && cn <= Nine {
+			return string(rune('0' + int(cn)))
+		}
+		return "?"
+	}
+}
+
+// Symbol はハンド履歴で使う小文字1文字の表記を返す
+func (cs CardSuit) Symbol() string {
+	switch cs {
+	case Spade:
+		return "s"
+	case Heart:
+		return "h"
+	case Diamond:
+		return "d"
+	case Club:
+		return "c"
+	default:
+		return "?"
+	}
+}
+
+// Short は "Ah" や "Td" のような2文字の表記を返す
+func (c Card) Short() string {
+	return c.Number.Symbol() + c.Suit.Symbol()
+}
+
+// ParseCard は "Ah" や "Td" のような2文字の表記からカードを作成する。"10h" のような表記も読み込める
+func ParseCard(s string) (Card, error) {
+	if len(s) < 2 {
+		return Card{}, ErrInvalidCard.With(s)
+	}
+	numberText, suitText := strings.ToUpper(s[:len(s)-1]), strings.ToLower(s[len(s)-1:])
+	var number CardNumber
+	for cn := Ace; cn <= King; cn++ {
+		if cn.Symbol() == numberText {
+			number = cn
+		}
+	}
+	if numberText == "10" {
+		number = Ten
+	}
+	var suit CardSuit
+	for cs := Spade; cs <= Club; cs++ {
+		if cs.Symbol() == suitText {
+			suit = cs
+		}
+	}
+	if number == 0 || suit == 0 {
+		return Card{}, ErrInvalidCard.With(s)
+	}
+	return Card{Suit: suit, Number: number}, nil
+}
+
+// ParseCards は空白区切りで並べたカードの表記を読み込む
+func ParseCards(s string) ([]Card, error) {
+	var cards []Card
+	for _, text := range strings.Fields(s) {
+		c, err := ParseCard(text)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, c)
+	}
+	return cards, nil
+}
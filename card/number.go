@@ -57,4 +57,11 @@ func (cn CardNumber) IsLarge(compareCardNumer CardNumber) bool {
 	} else {
 		return (cn > compareCardNumer)
 	}
-}
\ No newline at end of file
+}
+// Rank は強さの比較に使う値を返す。Aceは最も強い14になる
+func (cn CardNumber) Rank() int {
+	if cn == Ace {
+		return 14
+	}
+	return int(cn)
+}
@@ -2,8 +2,6 @@ package deck
 
 import (
 	"go_poker/card"
-	"math/rand"
-	"time"
 )
 
 type Deck struct {
@@ -53,8 +51,12 @@ func NewDeck() *Deck {
 }
 
 func (d *Deck) Shuffle() *Deck {
-	rand.Seed(time.Now().UnixNano())
-	rand.Shuffle(len(d.Cards), func(i, j int) {
+	return d.ShuffleWith(NewTimeSource())
+}
+
+// ShuffleWith は乱数源を使ってカードを混ぜる。同じ状態の乱数源からは同じ順番になる
+func (d *Deck) ShuffleWith(src *Source) *Deck {
+	src.Rand().Shuffle(len(d.Cards), func(i, j int) {
 		d.Cards[i], d.Cards[j] = d.Cards[j], d.Cards[i]
 	})
 	return d
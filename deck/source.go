@@ -0,0 +1,42 @@
+package deck
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Source はsplitmix64による乱数源で、math/randのSource64として使える
+// 状態はStateの64ビットだけなので、保存しておけば同じ乱数列を続きから再現できる
+type Source struct {
+	State uint64
+}
+
+func NewSource(seed int64) *Source {
+	return &Source{State: uint64(seed)}
+}
+
+// NewTimeSource は現在時刻を種にした乱数源を作成する
+func NewTimeSource() *Source {
+	return NewSource(time.Now().UnixNano())
+}
+
+func (s *Source) Seed(seed int64) {
+	s.State = uint64(seed)
+}
+
+func (s *Source) Uint64() uint64 {
+	s.State += 0x9e3779b97f4a7c15
+	z := s.State
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+func (s *Source) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+// Rand は乱数源をmath/randのRandとして返す。Randは状態を持たないため、何度作り直してもよい
+func (s *Source) Rand() *rand.Rand {
+	return rand.New(s)
+}
@@ -0,0 +1,107 @@
+package hand
+
+import (
+	"go_poker/card"
+	"sort"
+)
+
+// Compare は2つのハンドの強さを比べ、hが強ければ正、弱ければ負、同じ強さなら0を返す
+func (h *Hand) Compare(other *Hand) int {
+	return compare(h.Point, h.Ranks, other.Point, other.Ranks)
+}
+
+func compare(pointA HandPoint, ranksA []int, pointB HandPoint, ranksB []int) int {
+	if pointA != pointB {
+		return int(pointA) - int(pointB)
+	}
+	for i := 0; i < len(ranksA) && i < len(ranksB); i++ {
+		if ranksA[i] != ranksB[i] {
+			return ranksA[i] - ranksB[i]
+		}
+	}
+	return len(ranksA) - len(ranksB)
+}
+
+// evaluate は5枚以下のカードの手役と比較用の強さを計算する
+func evaluate(cards []card.Card) (HandPoint, []int, []card.Card) {
+	if len(cards) == 0 {
+		return HighCard, nil, nil
+	}
+	best := append([]card.Card{}, cards...)
+
+	// 枚数の多い順、同じ枚数なら強い順に数字をまとめる
+	counts := make(map[int]int, len(cards))
+	for _, c := range cards {
+		counts[c.Number.Rank()]++
+	}
+	groups := make([]int, 0, len(counts))
+	for rank := range counts {
+		groups = append(groups, rank)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if counts[groups[i]] != counts[groups[j]] {
+			return counts[groups[i]] > counts[groups[j]]
+		}
+		return groups[i] > groups[j]
+	})
+
+	straightHigh := 0
+	isFlush := false
+	if len(cards) == 5 {
+		isFlush = true
+		for _, c := range cards[1:] {
+			if c.Suit != cards[0].Suit {
+				isFlush = false
+			}
+		}
+		if len(groups) == 5 {
+			if groups[0]-groups[4] == 4 {
+				straightHigh = groups[0]
+			} else if groups[0] == 14 && groups[1] == 5 {
+				// A-2-3-4-5 のストレートはAceを1として扱う
+				straightHigh = 5
+			}
+		}
+	}
+
+	switch {
+	case isFlush && straightHigh == 14:
+		return RoyalFlush, []int{straightHigh}, best
+	case isFlush && straightHigh > 0:
+		return StraightFlush, []int{straightHigh}, best
+	case counts[groups[0]] == 4:
+		return FourOfAKind, groups, best
+	case counts[groups[0]] == 3 && len(groups) > 1 && counts[groups[1]] >= 2:
+		return AFullHouse, groups[:2], best
+	case isFlush:
+		return Flush, groups, best
+	case straightHigh > 0:
+		return Straight, []int{straightHigh}, best
+	case counts[groups[0]] == 3:
+		return ThreeOfAKind, groups, best
+	case counts[groups[0]] == 2 && len(groups) > 1 && counts[groups[1]] == 2:
+		return TwoPair, groups, best
+	case counts[groups[0]] == 2:
+		return OnePair, groups, best
+	default:
+		return HighCard, groups, best
+	}
+}
+
+// combinations はcardsからn枚を選ぶすべての組み合わせでfnを呼び出す
+func combinations(cards []card.Card, n int, fn func([]card.Card)) {
+	selected := make([]card.Card, 0, n)
+	var walk func(start int)
+	walk = func(start int) {
+		if len(selected) == n {
+			fn(selected)
+			return
+		}
+		for i := start; i <= len(cards)-(n-len(selected)); i++ {
+			selected = append(selected, cards[i])
+			walk(i + 1)
+			selected = selected[:len(selected)-1]
+		}
+	}
+	walk(0)
+}
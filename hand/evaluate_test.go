@@ -0,0 +1,97 @@
+package hand
+
+import (
+	"go_poker/card"
+	"testing"
+)
+
+// culc はホールカードとボードの表記から手役を計算する
+func culc(t *testing.T, holeCards, board string) *Hand {
+	t.Helper()
+	hole, err := card.ParseCards(holeCards)
+	if err != nil {
+		t.Fatal(err)
+	}
+	flop, err := card.ParseCards(board)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewHand(hole).Culc(flop)
+}
+
+func TestCulcHandPoint(t *testing.T) {
+	tests := []struct {
+		hole  string
+		board string
+		point HandPoint
+		ranks []int
+	}{
+		{"As Ks", "Qs Js Ts 2d 3c", RoyalFlush, []int{14}},
+		{"9s Ks", "Qs Js Ts 2d 3c", StraightFlush, []int{13}},
+		{"Ah 2h", "3h 4h 5h Kd Kc", StraightFlush, []int{5}},
+		{"Ah Ad", "Ac As Kd 2h 3c", FourOfAKind, []int{14, 13}},
+		{"Ah Ad", "Ac Kd Kc Ks 2h", AFullHouse, []int{14, 13}},
+		{"2h 3h", "4h 9h Kh Ad Ac", Flush, []int{13, 9, 4, 3, 2}},
+		{"7h 8d", "9c Ts Jh Qd Kc", Straight, []int{13}},
+		{"Ah 2d", "3c 4s 5h Kd Qc", Straight, []int{5}},
+		{"Th Jd", "Qc Ks Ah 2d 3c", Straight, []int{14}},
+		{"7h 7d", "7c Ks 2h 4d 9c", ThreeOfAKind, []int{7, 13, 9}},
+		{"2h 2d", "3c 3s 4h 4d Ac", TwoPair, []int{4, 3, 14}},
+		{"Ah Ad", "3c 8s Th Jd 2c", OnePair, []int{14, 11, 10, 8}},
+		{"Ah Kd", "9c 7s 5h 3d 2c", HighCard, []int{14, 13, 9, 7, 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.hole+" "+tt.board, func(t *testing.T) {
+			h := culc(t, tt.hole, tt.board)
+			if h.Point != tt.point {
+				t.Fatalf("Point = %s, want %s", h.Point, tt.point)
+			}
+			if len(h.Ranks) != len(tt.ranks) {
+				t.Fatalf("Ranks = %v, want %v", h.Ranks, tt.ranks)
+			}
+			for i := range tt.ranks {
+				if h.Ranks[i] != tt.ranks[i] {
+					t.Fatalf("Ranks = %v, want %v", h.Ranks, tt.ranks)
+				}
+			}
+			if len(h.BestCards) != 5 {
+				t.Errorf("BestCards = %d cards, want 5", len(h.BestCards))
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name  string
+		a     string
+		b     string
+		board string
+		want  int
+	}{
+		{"強い手役が勝つ", "Ah Ad", "Kh Qd", "2c 7s 9h Jd 3c", 1},
+		{"ペアのキッカーで決まる", "Ah Kd", "As Qh", "Ac 7s 9h Jd 3c", 1},
+		{"ツーペアの5枚目で決まる", "9h Kd", "9s Qh", "9c 7s 7h 2d 3c", 1},
+		{"ハイカードの5枚目まで比べる", "Ah 6d", "As 5h", "Kc 9s 8h 2d 3c", 1},
+		{"ボードの方が強いキッカーは使わない", "Ah 2d", "As 3h", "Ac Kc Qs Jd 9c", 0},
+		{"A-5のストレートは6ハイのストレートに負ける", "Ah 2d", "6s 2h", "3c 4s 5h Kd Kc", -1},
+		{"フラッシュは2枚目以降も比べる", "Kh 3h", "Kd 2h", "Ah 9h 5h 4c 7d", 1},
+		{"フルハウスは3枚組から比べる", "Kh Kd", "Qh Qd", "Kc Qs 2h 2d 7c", 1},
+		{"同じ5枚なら引き分け", "Ah Kd", "As Kh", "2c 3c 4c 8d 9d", 0},
+		{"ボードの役を共有すれば引き分け", "2h 3d", "4s 5h", "Tc Jc Qc Kc Ac", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := culc(t, tt.a, tt.board)
+			b := culc(t, tt.b, tt.board)
+			got := a.Compare(b)
+			switch {
+			case tt.want > 0 && got <= 0, tt.want < 0 && got >= 0, tt.want == 0 && got != 0:
+				t.Errorf("Compare(%s, %s) = %d, want sign %d", tt.a, tt.b, got, tt.want)
+			}
+			if reverse := b.Compare(a); (reverse > 0) != (got < 0) || (reverse == 0) != (got == 0) {
+				t.Errorf("Compare is not antisymmetric: %d and %d", got, reverse)
+			}
+		})
+	}
+}
@@ -3,7 +3,6 @@ package hand
 import (
 	"go_poker/card"
 	"go_poker/util"
-	"reflect"
 	"sort"
 )
 
@@ -11,6 +10,10 @@ type Hand struct {
 	Cards []card.Card
 	Point HandPoint
 	AddedFlopCards []card.Card
+	// BestCards は手役を構成する5枚
+	BestCards []card.Card
+	// Ranks は同じ手役同士を比べるための強さを、比較する順に並べたもの
+	Ranks []int
 }
 
 func NewHand(cards []card.Card) *Hand {
@@ -44,40 +47,21 @@ func (h *Hand) Suits() []card.CardSuit {
 	return results
 }
 
+// Culc はホールカードとボードから作れる最も強い5枚の組み合わせで手役を計算する
 func (h *Hand) Culc(flopCards []card.Card) *Hand {
-	h.AddedFlopCards = append(h.Cards, flopCards...)
-	gloupByNumberCards := h.GloupByNumber()
-	gloupByNumberCardLengths := make([]int, 0, len(gloupByNumberCards))
-	for _, v := range gloupByNumberCards {
-		gloupByNumberCardLengths = append(gloupByNumberCardLengths, len(v))
-	}
-
-	var maxpairOfNumberCards int
-	if len(gloupByNumberCards) > 0 {
-		sort.Sort(sort.IntSlice(gloupByNumberCardLengths))
-		maxpairOfNumberCards = gloupByNumberCardLengths[len(gloupByNumberCardLengths) - 1]
-	}
-
-	if h.IsRoyalFlush() {
-		h.Point = RoyalFlush
-	} else if h.IsFlush() && h.IsStraight() {
-		h.Point = StraightFlush
-	} else if maxpairOfNumberCards == 4 {
-		h.Point = FourOfAKind
-	} else if util.Contains(gloupByNumberCardLengths, 3) && util.Contains(gloupByNumberCardLengths, 2) {
-		h.Point = AFullHouse
-	} else if h.IsFlush() {
-		h.Point = Flush
-	} else if h.IsStraight() {
-		h.Point = Straight
-	} else if maxpairOfNumberCards == 3 {
-		h.Point = ThreeOfAKind
-	} else if len(gloupByNumberCards) == 2 {
-		h.Point = TwoPair
-	} else if len(gloupByNumberCards) == 1 {
-		h.Point = OnePair
+	h.AddedFlopCards = append(append([]card.Card{}, h.Cards...), flopCards...)
+	if len(h.AddedFlopCards) <= 5 {
+		h.Point, h.Ranks, h.BestCards = evaluate(h.AddedFlopCards)
+		return h
 	}
 
+	h.BestCards = nil
+	combinations(h.AddedFlopCards, 5, func(cards []card.Card) {
+		point, ranks, best := evaluate(cards)
+		if h.BestCards == nil || compare(point, ranks, h.Point, h.Ranks) > 0 {
+			h.Point, h.Ranks, h.BestCards = point, ranks, best
+		}
+	})
 	return h
 }
 
@@ -108,59 +92,3 @@ func (h *Hand) GloupBySUit() map[string][]card.Card {
 	}
 	return results
 }
-
-func (h *Hand) IsStraight() bool {
-	numbers := h.Numbers()
-	for i := 0; i < len(numbers); i++ {
-		if i >= len(numbers) - 1 {
-			return true
-		}
-		if numbers[i] != (numbers[i + 1] - 1) && !(numbers[i] == card.Ace && numbers[i + 1] == card.Ten) {
-			return false
-		}
-	}
-	return false
-}
-
-func (h *Hand) IsFlush() bool {
-	suits := h.Suits()
-	for i := 0; i < len(suits); i++ {
-		if i >= len(suits) - 1 {
-			return true
-		}
-		if suits[i] != suits[i + 1] {
-			return false
-		}
-	}
-	return false
-}
-
-func (h *Hand) IsRoyalFlush() bool {
-	if !h.IsStraight() || !h.IsFlush() {
-		return false
-	}
-	numbers := h.Numbers()
-	royalFlushNumbers := []card.CardNumber{
-		card.Ace,
-		card.Ten,
-		card.Jack,
-		card.Queen,
-		card.King,
-	}
-	return reflect.DeepEqual(numbers, royalFlushNumbers)
-}
-
-func (h *Hand) GetHandInnerThreeCardGroup() []card.Card {
-	// result := make([]card.Card, 0, len(h.AddedFlopCards))
-	var results []card.Card
-	for _, v := range h.GloupByNumber() {
-		if len(v) >= 3 {
-			results = v
-		}
-	}
-	return results
-}
-
-//func (h *Hand) CulcForDrawHandPoint() {
-//
-//}
\ No newline at end of file
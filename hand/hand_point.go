@@ -3,7 +3,8 @@ package hand
 type HandPoint int
 
 const (
-	OnePair HandPoint = iota + 1
+	HighCard HandPoint = iota
+	OnePair
 	TwoPair
 	ThreeOfAKind
 	Straight
@@ -0,0 +1,47 @@
+package i18n
+
+// Error はメッセージカタログで翻訳されるエラー
+// 文言はError()を呼んだときの言語で作るため、言語を切り替えても同じエラーを使える
+// キーが同じエラーは、埋め込む引数や原因のエラーが違ってもerrors.Isで一致する
+type Error struct {
+	Key  Message
+	Args []interface{}
+	Err  error
+}
+
+// NewError はerrors.Isで比較するための、引数を持たないエラーを作成する
+func NewError(key Message) *Error {
+	return &Error{Key: key}
+}
+
+// Errorf はメッセージに引数を埋め込んだエラーを作成する
+func Errorf(key Message, args ...interface{}) *Error {
+	return &Error{Key: key, Args: args}
+}
+
+// With は同じ種類で、メッセージに引数を埋め込んだエラーを返す
+func (e *Error) With(args ...interface{}) *Error {
+	return &Error{Key: e.Key, Args: args, Err: e.Err}
+}
+
+// Wrap は同じ種類で、原因となったエラーを持つエラーを返す。原因のエラーの文言はメッセージの後ろに続ける
+func (e *Error) Wrap(err error) *Error {
+	return &Error{Key: e.Key, Args: e.Args, Err: err}
+}
+
+func (e *Error) Error() string {
+	text := T(e.Key, e.Args...)
+	if e.Err != nil {
+		text += ": " + e.Err.Error()
+	}
+	return text
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Key == e.Key
+}
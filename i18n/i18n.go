@@ -0,0 +1,107 @@
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Language は画面やエラーに表示するメッセージの言語
+type Language int
+
+const (
+	Japanese Language = iota
+	English
+)
+
+func (l Language) String() string {
+	switch l {
+	case Japanese:
+		return "ja"
+	case English:
+		return "en"
+	default:
+		return "Unknown"
+	}
+}
+
+// Message はメッセージカタログのキー
+// キーはパッケージ名を先頭に付け、パッケージをまたいで重複しないようにする
+type Message string
+
+var (
+	current  = Japanese
+	catalogs = map[Language]map[Message]string{}
+)
+
+// Register は言語ごとのメッセージを登録する。各パッケージのinitから呼ぶ
+// メッセージはfmt.Sprintfの書式で、引数の順番は言語をまたいで揃える
+func Register(lang Language, messages map[Message]string) {
+	catalog, ok := catalogs[lang]
+	if !ok {
+		catalog = make(map[Message]string, len(messages))
+		catalogs[lang] = catalog
+	}
+	for key, text := range messages {
+		catalog[key] = text
+	}
+}
+
+// SetLanguage は表示する言語を切り替える。ゲームを始める前に呼ぶ
+func SetLanguage(lang Language) {
+	current = lang
+}
+
+// CurrentLanguage は表示している言語を返す
+func CurrentLanguage() Language {
+	return current
+}
+
+// ParseLanguage は "ja" や "en"、"ja_JP.UTF-8" のようなロケールの表記から言語を返す
+func ParseLanguage(s string) (Language, bool) {
+	s = strings.ToLower(s)
+	switch {
+	case s == "ja" || strings.HasPrefix(s, "ja_") || strings.HasPrefix(s, "ja-") || strings.HasPrefix(s, "ja."):
+		return Japanese, true
+	case s == "en" || strings.HasPrefix(s, "en_") || strings.HasPrefix(s, "en-") || strings.HasPrefix(s, "en."):
+		return English, true
+	default:
+		return Japanese, false
+	}
+}
+
+// DetectLanguage は環境変数LC_ALL、LC_MESSAGES、LANGの順にロケールを調べて言語を返す
+// 最初に見つかったロケールが日本語なら日本語、それ以外の言語なら英語にする
+// ロケールが設定されていないかCやPOSIXであれば日本語にする
+func DetectLanguage() Language {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		locale := os.Getenv(name)
+		if locale == "" {
+			continue
+		}
+		if lang, ok := ParseLanguage(locale); ok {
+			return lang
+		}
+		if locale == "C" || locale == "POSIX" || strings.HasPrefix(locale, "C.") {
+			return Japanese
+		}
+		return English
+	}
+	return Japanese
+}
+
+// T は現在の言語のメッセージに引数を埋め込んで返す
+// 現在の言語にメッセージがなければ日本語を、日本語にもなければキーをそのまま使う
+func T(key Message, args ...interface{}) string {
+	text, ok := catalogs[current][key]
+	if !ok {
+		text, ok = catalogs[Japanese][key]
+	}
+	if !ok {
+		text = string(key)
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
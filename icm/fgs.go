@@ -0,0 +1,145 @@
+package icm
+
+import "sort"
+
+// Blinds は将来のハンドで支払うブラインドとアンテ
+type Blinds struct {
+	SmallBlind int
+	BigBlind   int
+	Ante       int
+}
+
+// FutureGame は将来のハンドのシミュレーション(FGS)の設定
+type FutureGame struct {
+	// Button は次のハンドのボタンの座席
+	Button int
+	// Hands はシミュレーションするハンドごとのブラインドで、長さがハンド数になる
+	Hands []Blinds
+}
+
+// FutureGameEquity は将来のハンドでブラインドを支払うことを考慮した賞金の期待値を返す
+// 各ハンドではブラインドとアンテを支払い、残っている全員が同じ確率でポットを獲得するとみなす
+// ハンドでチップを失ったプレイヤーは、残りの最も低い順位の賞金を獲得する
+// シミュレーションの終わりの各局面をICMで評価するため、ハンド数は数ハンドに留めること
+func FutureGameEquity(stacks []int, prizes []int, game FutureGame) []float64 {
+	return simulate(stacks, prizes, game.Button, game.Hands)
+}
+
+func simulate(stacks []int, prizes []int, button int, hands []Blinds) []float64 {
+	if len(hands) == 0 || countLive(stacks) < 2 {
+		return Equity(stacks, prizes)
+	}
+
+	posted, pot := postBlinds(stacks, button, hands[0])
+	nextButton := nextLive(stacks, button)
+	equities := make([]float64, len(stacks))
+	winners := countLive(stacks)
+	for i := range stacks {
+		if stacks[i] <= 0 {
+			continue
+		}
+		next := make([]int, len(posted))
+		copy(next, posted)
+		next[i] += pot
+		paid, remaining := payBusted(stacks, next, prizes)
+		for j, equity := range simulate(next, remaining, nextButton, hands[1:]) {
+			equities[j] += (paid[j] + equity) / float64(winners)
+		}
+	}
+	return equities
+}
+
+// payBusted はハンドでチップを失ったプレイヤーに残りの最も低い順位の賞金を割り当て、生き残ったプレイヤーが争う賞金を返す
+// ハンドの前のスタックが多いプレイヤーほど上の順位になり、同じスタックのプレイヤーはそれぞれの順位の賞金を等分する
+func payBusted(before, after []int, prizes []int) ([]float64, []int) {
+	paid := make([]float64, len(before))
+	survivors := countLive(after)
+	busted := make([]int, 0, len(before))
+	for i := range before {
+		if before[i] > 0 && after[i] <= 0 {
+			busted = append(busted, i)
+		}
+	}
+	sort.SliceStable(busted, func(a, b int) bool {
+		return before[busted[a]] > before[busted[b]]
+	})
+
+	prize := func(place int) int {
+		if place < len(prizes) {
+			return prizes[place]
+		}
+		return 0
+	}
+	place := survivors
+	for start := 0; start < len(busted); {
+		end := start + 1
+		for end < len(busted) && before[busted[end]] == before[busted[start]] {
+			end++
+		}
+		total := 0
+		for k := start; k < end; k++ {
+			total += prize(place + k - start)
+		}
+		for k := start; k < end; k++ {
+			paid[busted[k]] = float64(total) / float64(end-start)
+		}
+		place += end - start
+		start = end
+	}
+
+	if survivors < len(prizes) {
+		prizes = prizes[:survivors]
+	}
+	return paid, prizes
+}
+
+// postBlinds はアンテとブラインドを支払った後のスタックとポットの額を返す
+// ヘッズアップではボタンがSBを支払い、スタックが足りないプレイヤーはオールインになる
+func postBlinds(stacks []int, button int, blinds Blinds) ([]int, int) {
+	posted := make([]int, len(stacks))
+	copy(posted, stacks)
+	pot := 0
+	pay := func(i, amount int) {
+		if amount > posted[i] {
+			amount = posted[i]
+		}
+		posted[i] -= amount
+		pot += amount
+	}
+
+	for i := range posted {
+		if posted[i] > 0 {
+			pay(i, blinds.Ante)
+		}
+	}
+	smallBlind := nextLive(stacks, button)
+	if countLive(stacks) == 2 {
+		smallBlind = button
+		if stacks[button] <= 0 {
+			smallBlind = nextLive(stacks, button)
+		}
+	}
+	pay(smallBlind, blinds.SmallBlind)
+	pay(nextLive(stacks, smallBlind), blinds.BigBlind)
+	return posted, pot
+}
+
+// nextLive は指定した座席の次にチップが残っているプレイヤーの座席を返す
+func nextLive(stacks []int, from int) int {
+	for i := 1; i <= len(stacks); i++ {
+		idx := (from + i) % len(stacks)
+		if stacks[idx] > 0 {
+			return idx
+		}
+	}
+	return from
+}
+
+func countLive(stacks []int) (result int) {
+	for _, stack := range stacks {
+		if stack > 0 {
+			result++
+		}
+	}
+	return result
+}
@@ -0,0 +1,68 @@
+package icm
+
+// Equity はMalmuth-Harvilleモデルで、チップ量と順位ごとの賞金から各プレイヤーの賞金の期待値を返す
+// 各順位になる確率は、残っているプレイヤーの中でのチップ量の割合で決まる
+// prizesは1位から順に並び、チップがないプレイヤーの期待値は0になる
+func Equity(stacks []int, prizes []int) []float64 {
+	c := calculator{
+		stacks: stacks,
+		prizes: prizes,
+		memo:   map[int][]float64{},
+	}
+	mask := 0
+	for i, stack := range stacks {
+		if stack > 0 {
+			mask |= 1 << i
+			c.players++
+		}
+	}
+
+	equities := make([]float64, len(stacks))
+	copy(equities, c.equity(mask))
+	return equities
+}
+
+type calculator struct {
+	stacks  []int
+	prizes  []int
+	players int
+	memo    map[int][]float64
+}
+
+// equity はmaskに含まれるプレイヤーで、残りの順位を争ったときの期待値を返す
+func (c *calculator) equity(mask int) []float64 {
+	place := c.players - countBits(mask)
+	if mask == 0 || place >= len(c.prizes) {
+		return nil
+	}
+	if result, ok := c.memo[mask]; ok {
+		return result
+	}
+
+	total := 0
+	for i, stack := range c.stacks {
+		if mask&(1<<i) != 0 {
+			total += stack
+		}
+	}
+	result := make([]float64, len(c.stacks))
+	for i, stack := range c.stacks {
+		if mask&(1<<i) == 0 {
+			continue
+		}
+		probability := float64(stack) / float64(total)
+		result[i] += probability * float64(c.prizes[place])
+		for j, equity := range c.equity(mask &^ (1 << i)) {
+			result[j] += probability * equity
+		}
+	}
+	c.memo[mask] = result
+	return result
+}
+
+func countBits(mask int) (result int) {
+	for ; mask != 0; mask &= mask - 1 {
+		result++
+	}
+	return result
+}
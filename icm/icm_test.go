@@ -0,0 +1,121 @@
+package icm
+
+import (
+	"math"
+	"testing"
+)
+
+// assertEquities は期待値を小数第2位まで比べる
+func assertEquities(t *testing.T, got, want []float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("equities = %v, want %v", got, want)
+	}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 0.005 {
+			t.Fatalf("equities = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestEquity(t *testing.T) {
+	tests := []struct {
+		name   string
+		stacks []int
+		prizes []int
+		want   []float64
+	}{
+		{"3人で3位まで賞金", []int{5000, 3000, 2000}, []int{50, 30, 20}, []float64{38.39, 32.75, 28.86}},
+		{"チップがないプレイヤーは0", []int{5000, 0, 3000, 2000}, []int{50, 30, 20}, []float64{38.39, 0, 32.75, 28.86}},
+		{"同じスタックなら等分", []int{1000, 1000}, []int{65, 35}, []float64{50, 50}},
+		{"1位だけならチップ量の割合", []int{5000, 3000, 2000}, []int{100}, []float64{50, 30, 20}},
+		{"賞金の順位より多い人数", []int{4000, 3000, 2000, 1000}, []int{70, 30}, []float64{37.48, 30.25, 21.24, 11.04}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertEquities(t, Equity(tt.stacks, tt.prizes), tt.want)
+		})
+	}
+}
+
+func TestFutureGameEquity(t *testing.T) {
+	stacks := []int{5000, 3000, 2000}
+	prizes := []int{50, 30, 20}
+
+	// シミュレーションするハンドがなければICMと同じになる
+	assertEquities(t, FutureGameEquity(stacks, prizes, FutureGame{}), Equity(stacks, prizes))
+
+	// SBの座席1とBBの座席2がブラインドを支払い、3人が同じ確率で1500のポットを獲得する
+	got := FutureGameEquity(stacks, prizes, FutureGame{Button: 0, Hands: []Blinds{{SmallBlind: 500, BigBlind: 1000}}})
+	assertEquities(t, got, []float64{39.87, 33.23, 26.90})
+
+	total := 0.0
+	for _, equity := range got {
+		total += equity
+	}
+	if math.Abs(total-100) > 1e-9 {
+		t.Errorf("total = %f, want 100", total)
+	}
+}
+
+func TestFutureGameEquityBust(t *testing.T) {
+	prizes := []int{50, 30, 20}
+	// BBの座席2は200でオールインし、ポットを獲得できなければ3位の賞金が確定する
+	oneBust := FutureGameEquity([]int{5000, 3000, 200}, prizes, FutureGame{Button: 0, Hands: []Blinds{{SmallBlind: 500, BigBlind: 1000}}})
+	want := make([]float64, 3)
+	outcomes := []struct {
+		stacks []int
+		paid   []float64
+	}{
+		{[]int{5700, 2500, 0}, []float64{0, 0, 20}},
+		{[]int{5000, 3200, 0}, []float64{0, 0, 20}},
+		{[]int{5000, 2500, 700}, []float64{0, 0, 0}},
+	}
+	for _, o := range outcomes {
+		for i, equity := range Equity(o.stacks, prizes) {
+			want[i] += (equity + o.paid[i]) / 3
+		}
+	}
+	assertEquities(t, oneBust, want)
+
+	// 同じスタックで同時にチップを失ったプレイヤーは、残りの順位の賞金を等分する
+	twoBusts := FutureGameEquity([]int{5000, 3000, 200, 200}, []int{50, 30, 15, 5}, FutureGame{Button: 3, Hands: []Blinds{{SmallBlind: 100, BigBlind: 200, Ante: 200}}})
+	if math.Abs(twoBusts[2]-twoBusts[3]) > 1e-9 {
+		t.Errorf("busted equities = %f, %f, want equal", twoBusts[2], twoBusts[3])
+	}
+	for name, equities := range map[string][]float64{"one bust": oneBust, "two busts": twoBusts} {
+		total := 0.0
+		for _, equity := range equities {
+			total += equity
+		}
+		if math.Abs(total-100) > 1e-9 {
+			t.Errorf("%s: total = %f, want 100", name, total)
+		}
+	}
+}
+
+func TestPostBlinds(t *testing.T) {
+	tests := []struct {
+		name   string
+		stacks []int
+		button int
+		posted []int
+		pot    int
+	}{
+		{"BBが足りなければオールイン", []int{4000, 4000, 200}, 0, []int{3990, 3890, 0}, 320},
+		{"ヘッズアップはボタンがSB", []int{4000, 0, 200}, 0, []int{3890, 0, 0}, 310},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			posted, pot := postBlinds(tt.stacks, tt.button, Blinds{SmallBlind: 100, BigBlind: 200, Ante: 10})
+			if pot != tt.pot {
+				t.Errorf("pot = %d, want %d", pot, tt.pot)
+			}
+			for i := range tt.posted {
+				if posted[i] != tt.posted[i] {
+					t.Fatalf("posted = %v, want %v", posted, tt.posted)
+				}
+			}
+		})
+	}
+}
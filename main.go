@@ -1,21 +1,287 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"go_poker/i18n"
 	"go_poker/poker"
 	"os"
 	"strconv"
 )
 
 func main() {
+	playerCount := flag.Int("players", 2, "number of seats (2-10)")
+	seatList := flag.String("seats", "", "seat list like \"Alice:3000:human,Bob:3000:bot\"")
+	oddChip := flag.String("oddchip", "button", "odd chip rule for split pots (button|suit)")
+	chipUnit := flag.Int("chipunit", 1, "lowest chip denomination used to split pots")
+	structure := flag.String("structure", "nl", "betting structure (nl|pl|fl)")
+	raiseCap := flag.Int("raisecap", 4, "number of bets allowed per round in fixed-limit, 0 for no cap")
+	ante := flag.Int("ante", 0, "ante paid by each player, or by the big blind with -bbante")
+	bigBlindAnte := flag.Bool("bbante", false, "the big blind pays a single ante for the table")
+	straddle := flag.String("straddle", "none", "voluntary straddle (none|utg|button)")
+	reStraddles := flag.Int("restraddles", 0, "number of re-straddles allowed after the first straddle")
+	runs := flag.Int("runs", 1, "maximum number of times the rest of the board can be run when everyone is all-in (1-3)")
+	blindFile := flag.String("blinds", "", "JSON file with the blind level schedule")
+	historyFile := flag.String("history", "", "file to append hand histories to after every hand (.json: Open Hand History, .phhs: PHH, otherwise PokerStars)")
+	mode := flag.String("mode", "session", "game mode (session|cash|sng)")
+	minBuyIn := flag.Int("minbuyin", 0, "minimum buy-in in cash mode, 10 big blinds if 0")
+	maxBuyIn := flag.Int("maxbuyin", 0, "maximum buy-in in cash mode, 100 big blinds if 0")
+	buyIn := flag.Int("buyin", 100, "buy-in paid by each player in sit-and-go mode")
+	startingStack := flag.Int("stack", 1500, "starting stack in sit-and-go mode")
+	payoutList := flag.String("payouts", "50/30/20", "prize percentages by place in sit-and-go mode")
+	replayFile := flag.String("replay", "", "hand history file to step through instead of playing")
+	saveFile := flag.String("save", "", "file to save the game to when quitting, to be continued with -resume")
+	resumeFile := flag.String("resume", "", "saved game file to continue instead of starting a new game")
+	language := flag.String("lang", i18n.DetectLanguage().String(), "language of messages (ja|en), taken from LC_ALL, LC_MESSAGES or LANG by default")
+	flag.Parse()
+
+	lang, ok := i18n.ParseLanguage(*language)
+	if !ok {
+		fmt.Println(i18n.T(msgInvalidLanguage))
+		os.Exit(1)
+	}
+	i18n.SetLanguage(lang)
+
+	if *replayFile != "" {
+		if err := replay(*replayFile); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+	if *resumeFile != "" {
+		p, err := poker.LoadGame(*resumeFile)
+		if err == nil {
+			err = play(p, *historyFile, *saveFile, true)
+		}
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	bigBlind := 200
 	smallBilnd := 100
 	playerInitMoney := 3000
-	if len(os.Args) > 3 {
-		bigBlind, _ = strconv.Atoi(os.Args[1])
-		smallBilnd, _ = strconv.Atoi(os.Args[2])
-		playerInitMoney, _ = strconv.Atoi(os.Args[3])
+	args := flag.Args()
+	if len(args) > 2 {
+		bigBlind, _ = strconv.Atoi(args[0])
+		smallBilnd, _ = strconv.Atoi(args[1])
+		playerInitMoney, _ = strconv.Atoi(args[2])
+	}
+
+	seats := poker.DefaultSeats(*playerCount, playerInitMoney)
+	if *seatList != "" {
+		var err error
+		seats, err = poker.ParseSeats(*seatList)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	var tournament *poker.Tournament
+	switch *mode {
+	case "session", "cash":
+	case "sng":
+		payouts, err := poker.ParsePayouts(*payoutList)
+		if err == nil {
+			tournament, err = poker.NewTournament(*buyIn, *startingStack, payouts)
+		}
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		for i := range seats {
+			seats[i].Money = tournament.StartingStack
+		}
+	default:
+		fmt.Println(i18n.T(msgInvalidMode))
+		os.Exit(1)
+	}
+
+	var schedule *poker.BlindSchedule
+	if *blindFile != "" {
+		var err error
+		schedule, err = poker.LoadBlindSchedule(*blindFile)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	} else if tournament != nil {
+		schedule = poker.DefaultTournamentSchedule(tournament.StartingStack)
+	}
+	if schedule != nil {
+		bigBlind = schedule.Levels[0].BigBlind
+		smallBilnd = schedule.Levels[0].SmallBlind
+	}
+
+	var cashGame *poker.CashGame
+	if *mode == "cash" {
+		if *minBuyIn == 0 {
+			*minBuyIn = bigBlind * 10
+		}
+		if *maxBuyIn == 0 {
+			*maxBuyIn = bigBlind * 100
+		}
+		var err error
+		cashGame, err = poker.NewCashGame(*minBuyIn, *maxBuyIn)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		for _, seat := range seats {
+			if err := cashGame.ValidateBuyIn(seat.Money); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+	}
+
+	p := poker.NewPoker(bigBlind, smallBilnd, seats)
+	if p == nil {
+		os.Exit(1)
+	}
+	p.Schedule = schedule
+	p.Tournament = tournament
+	p.CashGame = cashGame
+	if *oddChip == "suit" {
+		p.OddChipRule = poker.OddChipHighSuit
+	}
+	p.ChipUnit = *chipUnit
+	bettingStructure, ok := poker.ParseBettingStructure(*structure)
+	if !ok {
+		fmt.Println(i18n.T(msgInvalidStructure))
+		os.Exit(1)
+	}
+	p.Structure = bettingStructure
+	p.RaiseCap = *raiseCap
+	straddleType, ok := poker.ParseStraddleType(*straddle)
+	if !ok {
+		fmt.Println(i18n.T(msgInvalidStraddle))
+		os.Exit(1)
+	}
+	p.Ante = *ante
+	p.BigBlindAnte = *bigBlindAnte
+	p.Straddle = straddleType
+	p.MaxReStraddles = *reStraddles
+	if *runs < 1 || *runs > poker.MaxRunouts {
+		fmt.Println(i18n.T(msgInvalidRuns, poker.MaxRunouts))
+		os.Exit(1)
 	}
+	p.MaxRuns = *runs
+	if err := play(p, *historyFile, *saveFile, false); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+// play はTUIでゲームを進め、終了したら結果を表示する
+// savePathを指定すると、ゲームの途中で終了したときに続きから再開できるよう保存する
+func play(p *poker.Poker, historyPath, savePath string, isResumed bool) error {
+	if historyPath != "" {
+		f, err := os.OpenFile(historyPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		hero := ""
+		for _, player := range p.Players {
+			if player.Controller == poker.Human {
+				hero = player.Name
+				break
+			}
+		}
+		w := poker.NewHandHistoryWriter(f, poker.HandHistoryFormatFor(historyPath), hero)
+		p.AddListener(w)
+		defer w.Flush()
+	}
+
+	// 最終順位と賞金は、ゲームが最後まで終わった場合だけ表示する
+	isGameEnded := false
+	p.AddListener(poker.ListenerFunc(func(e poker.Event) {
+		if _, ok := e.(*poker.GameEnded); ok {
+			isGameEnded = true
+		}
+	}))
+
+	v := poker.NewViewer(p)
+	start := v.Start
+	if isResumed {
+		start = v.Resume
+	}
+	if err := start(); err != nil {
+		return err
+	}
+	if savePath != "" && !p.IsGameOver() {
+		if err := p.SaveGame(savePath); err != nil {
+			return err
+		}
+		fmt.Println(i18n.T(msgGameSaved, savePath))
+	}
+
+	if p.Tournament != nil {
+		if isGameEnded {
+			fmt.Print(p.Tournament.Report(p.Players))
+		} else {
+			fmt.Print(p.Tournament.InterimReport(p.Players))
+		}
+	}
+	if p.CashGame != nil {
+		for _, player := range p.Players {
+			fmt.Printf("%s: %s\n", player.Name, player.GetNetResultString())
+		}
+	}
+	return nil
+}
+
+// replay はハンド履歴ファイルを読み込み、TUIで再生する
+// 読み込めなかったハンドがあっても、読み込めたハンドだけで再生を始める
+func replay(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	logs, err := poker.ReadHandHistories(f, poker.HandHistoryFormatFor(path))
+	if err != nil {
+		if len(logs) == 0 {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, err)
+	}
+	r, err := poker.NewReplay(logs)
+	if err != nil {
+		return err
+	}
+	return poker.NewReplayViewer(r).Start()
+}
+
+const (
+	msgInvalidLanguage  i18n.Message = "main.invalid_language"
+	msgInvalidMode      i18n.Message = "main.invalid_mode"
+	msgInvalidStructure i18n.Message = "main.invalid_structure"
+	msgInvalidStraddle  i18n.Message = "main.invalid_straddle"
+	msgInvalidRuns      i18n.Message = "main.invalid_runs"
+	msgGameSaved        i18n.Message = "main.game_saved"
+)
 
-	p := poker.NewPoker(bigBlind, smallBilnd, playerInitMoney)
-	p.InitSetUp()
+func init() {
+	i18n.Register(i18n.Japanese, map[i18n.Message]string{
+		msgInvalidLanguage:  "言語はja, enのいずれかを指定してください",
+		msgInvalidMode:      "ゲームモードはsession, cash, sngのいずれかを指定してください",
+		msgInvalidStructure: "ベットのルールはnl, pl, flのいずれかを指定してください",
+		msgInvalidStraddle:  "ストラドルはnone, utg, buttonのいずれかを指定してください",
+		msgInvalidRuns:      "ボードを配る回数は1から%dの間で指定してください",
+		msgGameSaved:        "ゲームを%sに保存しました",
+	})
+	i18n.Register(i18n.English, map[i18n.Message]string{
+		msgInvalidLanguage:  "The language must be ja or en",
+		msgInvalidMode:      "The game mode must be session, cash or sng",
+		msgInvalidStructure: "The betting structure must be nl, pl or fl",
+		msgInvalidStraddle:  "The straddle must be none, utg or button",
+		msgInvalidRuns:      "The number of runs must be between 1 and %d",
+		msgGameSaved:        "Saved the game to %s",
+	})
 }
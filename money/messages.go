@@ -0,0 +1,17 @@
+package money
+
+import "go_poker/i18n"
+
+const msgNotEnoughMoney i18n.Message = "money.not_enough_money"
+
+// ErrNotEnoughMoney は所持金を超える額をベットしようとしたときのエラー
+var ErrNotEnoughMoney = i18n.NewError(msgNotEnoughMoney)
+
+func init() {
+	i18n.Register(i18n.Japanese, map[i18n.Message]string{
+		msgNotEnoughMoney: "ベット額が足りません",
+	})
+	i18n.Register(i18n.English, map[i18n.Message]string{
+		msgNotEnoughMoney: "Not enough money to bet",
+	})
+}
@@ -0,0 +1,141 @@
+package poker
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// BlindLevel はブラインドストラクチャーの1レベル
+// MinutesかHandsのどちらかが経過すると次のレベルへ進む。両方が0のレベルは最後まで続く
+type BlindLevel struct {
+	SmallBlind int `json:"small_blind"`
+	BigBlind   int `json:"big_blind"`
+	Ante       int `json:"ante"`
+	Minutes    int `json:"minutes"`
+	Hands      int `json:"hands"`
+}
+
+func (l BlindLevel) String() string {
+	if l.Ante > 0 {
+		return fmt.Sprintf("%d/%d (ante %d)", l.SmallBlind, l.BigBlind, l.Ante)
+	}
+	return fmt.Sprintf("%d/%d", l.SmallBlind, l.BigBlind)
+}
+
+// BlindSchedule はハンドの間に順に上がっていくブラインドのレベル
+type BlindSchedule struct {
+	Levels []BlindLevel `json:"levels"`
+}
+
+// LoadBlindSchedule はJSONファイルからブラインドストラクチャーを読み込む
+func LoadBlindSchedule(path string) (*BlindSchedule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	schedule := &BlindSchedule{}
+	if err := json.Unmarshal(data, schedule); err != nil {
+		return nil, ErrLoadBlindSchedule.Wrap(err)
+	}
+	if err := schedule.Validate(); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+func (s *BlindSchedule) Validate() error {
+	if len(s.Levels) == 0 {
+		return ErrNoBlindLevels
+	}
+	for i, level := range s.Levels {
+		if level.SmallBlind <= 0 || level.BigBlind < level.SmallBlind {
+			return ErrInvalidLevelBlinds.With(i + 1)
+		}
+		if level.Ante < 0 || level.Minutes < 0 || level.Hands < 0 {
+			return ErrNegativeLevel.With(i + 1)
+		}
+		if i < len(s.Levels)-1 && level.Minutes == 0 && level.Hands == 0 {
+			return ErrLevelLength.With(i + 1)
+		}
+	}
+	return nil
+}
+
+// CurrentBlindLevel は現在のブラインドレベルを返す
+func (p *Poker) CurrentBlindLevel() (BlindLevel, bool) {
+	if p.Schedule == nil {
+		return BlindLevel{}, false
+	}
+	return p.Schedule.Levels[p.Level], true
+}
+
+// LevelRemaining は次のレベルまでの残り時間と残りハンド数を返す
+// 時間やハンド数で区切られていなければ負の値を返す
+func (p *Poker) LevelRemaining(now time.Time) (time.Duration, int) {
+	level, ok := p.CurrentBlindLevel()
+	if !ok || p.Level >= len(p.Schedule.Levels)-1 {
+		return -1, -1
+	}
+	remainingTime := time.Duration(-1)
+	if level.Minutes > 0 {
+		remainingTime = p.LevelStartedAt.Add(time.Duration(level.Minutes) * time.Minute).Sub(now)
+		if remainingTime < 0 {
+			remainingTime = 0
+		}
+	}
+	remainingHands := -1
+	if level.Hands > 0 {
+		remainingHands = level.Hands - (p.HandCount - p.LevelStartedHand)
+		if remainingHands < 0 {
+			remainingHands = 0
+		}
+	}
+	return remainingTime, remainingHands
+}
+
+// updateBlindLevel はハンドの開始前に、時間かハンド数が経過していればブラインドを次のレベルへ上げる
+func (p *Poker) updateBlindLevel(now time.Time) {
+	if p.Schedule == nil {
+		return
+	}
+	if p.LevelStartedHand == 0 {
+		p.Level = 0
+		p.LevelStartedAt = now
+		p.LevelStartedHand = p.HandCount
+		p.applyBlindLevel()
+		return
+	}
+
+	for p.Level < len(p.Schedule.Levels)-1 && p.isLevelExpired(now) {
+		level := p.Schedule.Levels[p.Level]
+		if level.Minutes > 0 && now.Sub(p.LevelStartedAt) >= time.Duration(level.Minutes)*time.Minute {
+			p.LevelStartedAt = p.LevelStartedAt.Add(time.Duration(level.Minutes) * time.Minute)
+		} else {
+			p.LevelStartedAt = now
+		}
+		p.LevelStartedHand = p.HandCount
+		p.Level++
+		p.applyBlindLevel()
+		p.emit(&BlindLevelRaised{Level: p.Level, Blinds: p.Schedule.Levels[p.Level]})
+	}
+}
+
+func (p *Poker) isLevelExpired(now time.Time) bool {
+	level := p.Schedule.Levels[p.Level]
+	if level.Minutes > 0 && now.Sub(p.LevelStartedAt) >= time.Duration(level.Minutes)*time.Minute {
+		return true
+	}
+	return level.Hands > 0 && p.HandCount-p.LevelStartedHand >= level.Hands
+}
+
+// applyBlindLevel は現在のレベルのブラインドとアンテをテーブルに反映する
+func (p *Poker) applyBlindLevel() {
+	level := p.Schedule.Levels[p.Level]
+	p.SmollBlind = level.SmallBlind
+	p.BigBlind = level.BigBlind
+	p.Ante = level.Ante
+	p.SmallBet = level.BigBlind
+	p.BigBet = level.BigBlind * 2
+}
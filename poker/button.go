@@ -0,0 +1,132 @@
+package poker
+
+// initButton は最初のハンドのボタンとブラインドの座席を決める
+func (p *Poker) initButton(button int) {
+	p.Button = button
+	if p.countSeatedPlayers() == 2 {
+		// ヘッズアップではボタンがSBを支払う
+		p.SmallBlindIndex = p.Button
+	} else {
+		p.SmallBlindIndex = p.nextSeatedIndex(p.Button)
+	}
+	p.BigBlindIndex = p.nextSeatedIndex(p.SmallBlindIndex)
+	p.assignPositions()
+}
+
+// MoveButton はハンド終了後にボタンを時計回りに1つ進める
+// BBは必ず次のプレイヤーへ進み、飛んだプレイヤーの座席にはデッドボタンやデッドSBが残る
+func (p *Poker) MoveButton() *Poker {
+	for _, player := range p.Players {
+		if player.Money <= 0 {
+			player.IsBusted = true
+		}
+	}
+	if p.countSeatedPlayers() < 2 {
+		return p
+	}
+
+	nextBigBlind := p.nextSeatedIndex(p.BigBlindIndex)
+	if p.countSeatedPlayers() == 2 {
+		// ヘッズアップではBBでない方のプレイヤーがボタンとSBを兼ねる
+		p.Button = p.nextSeatedIndex(nextBigBlind)
+		p.SmallBlindIndex = p.Button
+	} else {
+		p.Button = p.SmallBlindIndex
+		p.SmallBlindIndex = p.BigBlindIndex
+	}
+	p.BigBlindIndex = nextBigBlind
+	p.assignPositions()
+	return p
+}
+
+// IsDeadButton はボタンの座席が空席になっているかを返す
+func (p *Poker) IsDeadButton() bool {
+	return !p.Players[p.Button].isDealtIn()
+}
+
+// IsDeadSmallBlind はSBの座席が空席でSBが支払われないかを返す
+func (p *Poker) IsDeadSmallBlind() bool {
+	return !p.Players[p.SmallBlindIndex].isDealtIn()
+}
+
+// assignPositions はボタンとブラインドを基準に着席中のプレイヤーへポジションを割り当てる
+func (p *Poker) assignPositions() {
+	var seats []int
+	for i, player := range p.Players {
+		player.Position = 0
+		if player.isDealtIn() {
+			seats = append(seats, i)
+		}
+	}
+	for i, position := range seatPositions(seats, p.Button, p.SmallBlindIndex, p.BigBlindIndex) {
+		p.Players[seats[i]].Position = position
+	}
+}
+
+// seatPositions は昇順に並べた着席中の座席ごとに、ボタンとブラインドの座席を基準にしたポジションを返す
+// 空席のボタンとSBにはポジションを割り当てず、BBより後ろの座席にはUTGから順に残りの人数に応じたポジションを割り当てる
+// BBの座席がなければ、ボタンから時計回りの順にポジションを割り当てる
+func seatPositions(seats []int, button, smallBlind, bigBlind int) []Position {
+	result := make([]Position, len(seats))
+	if len(seats) == 0 {
+		return result
+	}
+	bb := -1
+	for i, seat := range seats {
+		switch seat {
+		case bigBlind:
+			bb = i
+		case button:
+			result[i] = BTN
+		case smallBlind:
+			result[i] = SB
+		}
+	}
+	if bb < 0 {
+		start := 0
+		for start < len(seats) && seats[start] < button {
+			start++
+		}
+		result = make([]Position, len(seats))
+		positions := PositionsFor(len(seats))
+		for i := range seats {
+			if i < len(positions) {
+				result[(start+i)%len(seats)] = positions[i]
+			}
+		}
+		return result
+	}
+	result[bb] = BB
+
+	var others []int
+	for i := (bb + 1) % len(seats); result[i] == 0; i = (i + 1) % len(seats) {
+		others = append(others, i)
+	}
+	positions := PositionsFor(len(others) + 3)
+	for i, index := range others {
+		if 3+i < len(positions) {
+			result[index] = positions[3+i]
+		}
+	}
+	return result
+}
+
+// nextSeatedIndex は指定した座席の次に着席しているプレイヤーの座席を返す
+func (p *Poker) nextSeatedIndex(from int) int {
+	for i := 1; i <= len(p.Players); i++ {
+		idx := (from + i) % len(p.Players)
+		if p.Players[idx].isDealtIn() {
+			return idx
+		}
+	}
+	return from
+}
+
+func (p *Poker) countSeatedPlayers() (result int) {
+	for _, player := range p.Players {
+		if player.isDealtIn() {
+			result++
+		}
+	}
+	return result
+}
@@ -0,0 +1,131 @@
+package poker
+
+import (
+	"testing"
+)
+
+// positionsOf は座席ごとのポジションを返す
+func positionsOf(p *Poker) []Position {
+	positions := make([]Position, len(p.Players))
+	for i, player := range p.Players {
+		positions[i] = player.Position
+	}
+	return positions
+}
+
+// assertRecordedPositions はハンド履歴から割り当てたポジションがエンジンと一致するかを確かめる
+func assertRecordedPositions(t *testing.T, p *Poker) {
+	t.Helper()
+	started := p.handStarted()
+	assignPositions(started)
+	for _, seat := range started.Seats {
+		if seat.Position != p.Players[seat.Seat].Position {
+			t.Errorf("recorded position of seat %d = %s, want %s", seat.Seat, seat.Position, p.Players[seat.Seat].Position)
+		}
+	}
+}
+
+func TestMoveButton(t *testing.T) {
+	tests := []struct {
+		name               string
+		players            int
+		bust               int
+		button, sb, bb     int
+		deadButton, deadSB bool
+		positions          []Position
+	}{
+		{
+			name:    "SBが飛ぶとボタンが空席に残る",
+			players: 5, bust: 1,
+			button: 1, sb: 2, bb: 3, deadButton: true,
+			positions: []Position{CO, 0, SB, BB, UTG},
+		},
+		{
+			name:    "BBが飛ぶとSBが空席に残る",
+			players: 5, bust: 2,
+			button: 1, sb: 2, bb: 3, deadSB: true,
+			positions: []Position{CO, BTN, 0, BB, UTG},
+		},
+		{
+			name:    "ボタンが飛んでヘッズアップになるとBBでない方がボタンとSBを兼ねる",
+			players: 3, bust: 0,
+			button: 2, sb: 2, bb: 1,
+			positions: []Position{0, BB, BTN},
+		},
+		{
+			name:    "BBが飛んでヘッズアップになる",
+			players: 3, bust: 2,
+			button: 1, sb: 1, bb: 0,
+			positions: []Position{BB, BTN, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestPoker(t, 100, 50, equalStacks(tt.players, 1000)...)
+			if p.Button != 0 || p.SmallBlindIndex != 1 || p.BigBlindIndex != 2 {
+				t.Fatalf("initial button = %d/%d/%d, want 0/1/2", p.Button, p.SmallBlindIndex, p.BigBlindIndex)
+			}
+			p.Players[tt.bust].Money = 0
+			p.MoveButton()
+
+			if p.Button != tt.button || p.SmallBlindIndex != tt.sb || p.BigBlindIndex != tt.bb {
+				t.Errorf("button/sb/bb = %d/%d/%d, want %d/%d/%d", p.Button, p.SmallBlindIndex, p.BigBlindIndex, tt.button, tt.sb, tt.bb)
+			}
+			if p.IsDeadButton() != tt.deadButton || p.IsDeadSmallBlind() != tt.deadSB {
+				t.Errorf("dead button = %v, dead SB = %v, want %v and %v", p.IsDeadButton(), p.IsDeadSmallBlind(), tt.deadButton, tt.deadSB)
+			}
+			got := positionsOf(p)
+			for i := range tt.positions {
+				if got[i] != tt.positions[i] {
+					t.Fatalf("positions = %v, want %v", got, tt.positions)
+				}
+			}
+			assertRecordedPositions(t, p)
+		})
+	}
+}
+
+func TestBigBlindMovesEveryHand(t *testing.T) {
+	p := newTestPoker(t, 100, 50, equalStacks(6, 1000)...)
+	// ハンドごとに飛ぶ座席。着席しているBBかUTGを飛ばし、最後はヘッズアップになる
+	busts := map[int]func() int{
+		2: func() int { return p.BigBlindIndex },
+		4: func() int { return p.nextSeatedIndex(p.BigBlindIndex) },
+		6: func() int { return p.BigBlindIndex },
+		8: func() int { return p.nextSeatedIndex(p.BigBlindIndex) },
+	}
+	for hand := 1; hand <= 12; hand++ {
+		if bust, ok := busts[hand]; ok {
+			p.Players[bust()].Money = 0
+		}
+		previous := p.BigBlindIndex
+		previousSeated := p.Players[previous].Money > 0
+		p.MoveButton()
+
+		if p.BigBlindIndex == previous {
+			t.Fatalf("hand %d: seat %d posts the BB twice in a row", hand, previous)
+		}
+		// 飛んだプレイヤーを除いて、BBは時計回りで次のプレイヤーへ進む
+		if want := p.nextSeatedIndex(previous); previousSeated && p.BigBlindIndex != want {
+			t.Fatalf("hand %d: BB = %d, want %d", hand, p.BigBlindIndex, want)
+		}
+		if !p.Players[p.BigBlindIndex].isDealtIn() {
+			t.Fatalf("hand %d: BB seat %d is empty", hand, p.BigBlindIndex)
+		}
+		assertRecordedPositions(t, p)
+	}
+	if p.countSeatedPlayers() != 2 || p.Button != p.SmallBlindIndex {
+		t.Errorf("seated = %d, button = %d, SB = %d, want heads-up with the button on the SB", p.countSeatedPlayers(), p.Button, p.SmallBlindIndex)
+	}
+}
+
+func TestSeatPositionsWithoutBigBlind(t *testing.T) {
+	// BBの支払いがない履歴では、ボタンから時計回りの順に割り当てる
+	got := seatPositions([]int{0, 2, 3}, 2, -1, -1)
+	want := []Position{BB, BTN, SB}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("positions = %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package poker
+
+// CashGame はキャッシュゲームのテーブルごとのバイインの設定
+type CashGame struct {
+	MinBuyIn int
+	MaxBuyIn int
+}
+
+func NewCashGame(minBuyIn, maxBuyIn int) (*CashGame, error) {
+	if minBuyIn <= 0 || maxBuyIn < minBuyIn {
+		return nil, ErrInvalidBuyInRange
+	}
+	return &CashGame{
+		MinBuyIn: minBuyIn,
+		MaxBuyIn: maxBuyIn,
+	}, nil
+}
+
+// ValidateBuyIn はバイイン額がテーブルの下限と上限の範囲内かを確認する
+func (c *CashGame) ValidateBuyIn(amount int) error {
+	if amount < c.MinBuyIn || amount > c.MaxBuyIn {
+		return ErrBuyInOutOfRange.With(c.MinBuyIn, c.MaxBuyIn)
+	}
+	return nil
+}
+
+// checkBetweenHands はキャッシュゲームのハンドとハンドの間であるかを確認する
+func (p *Poker) checkBetweenHands() error {
+	if p.CashGame == nil {
+		return ErrNotCashGame
+	}
+	if !p.IsHandFinished {
+		return ErrNotBetweenHands
+	}
+	return nil
+}
+
+// Rebuy はチップがなくなったプレイヤーに再びバイインさせる
+func (p *Poker) Rebuy(player *Player, amount int) error {
+	if err := p.checkBetweenHands(); err != nil {
+		return err
+	}
+	if player.HasLeft {
+		return ErrLeftPlayerRebuy
+	}
+	if player.Money > 0 {
+		return ErrHasChips
+	}
+	if err := p.CashGame.ValidateBuyIn(amount); err != nil {
+		return err
+	}
+	player.Money = amount
+	player.BuyIn += amount
+	player.IsBusted = false
+	p.emit(&SeatChanged{Player: player, Change: Rebought, Amount: amount})
+	return nil
+}
+
+// TopUp はスタックがバイインの上限を超えない範囲でチップを買い足させる
+func (p *Poker) TopUp(player *Player, amount int) error {
+	if err := p.checkBetweenHands(); err != nil {
+		return err
+	}
+	if player.HasLeft {
+		return ErrLeftPlayerTopUp
+	}
+	if player.Money <= 0 {
+		return p.Rebuy(player, amount)
+	}
+	if amount <= 0 || player.Money+amount > p.CashGame.MaxBuyIn {
+		return ErrTopUpOverMax.With(p.CashGame.MaxBuyIn)
+	}
+	player.Money += amount
+	player.BuyIn += amount
+	p.emit(&SeatChanged{Player: player, Change: ToppedUp, Amount: amount})
+	return nil
+}
+
+// TopUpToMax はスタックをバイインの上限まで買い足させる。チップがなければ上限額でリバイする
+func (p *Poker) TopUpToMax(player *Player) error {
+	if p.CashGame == nil {
+		return ErrNotCashGame
+	}
+	if player.Money >= p.CashGame.MaxBuyIn {
+		return ErrAlreadyMaxBuyIn
+	}
+	return p.TopUp(player, p.CashGame.MaxBuyIn-player.Money)
+}
+
+// SitOut は席を確保したまま、次のハンドから配られないようにする
+func (p *Poker) SitOut(player *Player) error {
+	if err := p.checkBetweenHands(); err != nil {
+		return err
+	}
+	player.IsSittingOut = true
+	p.emit(&SeatChanged{Player: player, Change: SatOut})
+	return nil
+}
+
+// SitIn は離席していたプレイヤーを次のハンドから戻す
+func (p *Poker) SitIn(player *Player) error {
+	if err := p.checkBetweenHands(); err != nil {
+		return err
+	}
+	player.IsSittingOut = false
+	p.emit(&SeatChanged{Player: player, Change: SatIn})
+	return nil
+}
+
+// Leave はプレイヤーをスタックを持ったままテーブルから離れさせ、座席を空ける
+func (p *Poker) Leave(player *Player) error {
+	if err := p.checkBetweenHands(); err != nil {
+		return err
+	}
+	player.HasLeft = true
+	p.emit(&SeatChanged{Player: player, Change: Left, Amount: player.Money})
+	return nil
+}
+
+// Join は空いている座席に新しいプレイヤーを着席させる
+// 離れたプレイヤーの座席があればそこに座り、なければテーブルの最後に座席を追加する
+// 着席しているプレイヤーと同じ名前では着席できない
+func (p *Poker) Join(seat Seat) (*Player, error) {
+	if err := p.checkBetweenHands(); err != nil {
+		return nil, err
+	}
+	if seat.Name == "" {
+		return nil, ErrNoPlayerName
+	}
+	for _, seated := range p.Players {
+		if !seated.HasLeft && seated.Name == seat.Name {
+			return nil, ErrDuplicateName.With(seat.Name)
+		}
+	}
+	if err := p.CashGame.ValidateBuyIn(seat.Money); err != nil {
+		return nil, err
+	}
+	player := NewPlayer(seat.Name, seat.Money, 0, seat.Controller)
+	for i, seated := range p.Players {
+		if seated.HasLeft {
+			p.Players[i] = player
+			p.emit(&SeatChanged{Player: player, Change: Joined, Amount: seat.Money, Seat: i})
+			return player, nil
+		}
+	}
+	if len(p.Players) >= MaxSeats {
+		return nil, ErrNoEmptySeat
+	}
+	p.Players = append(p.Players, player)
+	p.emit(&SeatChanged{Player: player, Change: Joined, Amount: seat.Money, Seat: len(p.Players) - 1})
+	return player, nil
+}
+
+// rebuyBots はチップがなくなったBotにバイインの上限額でリバイさせる
+func (p *Poker) rebuyBots() {
+	for _, player := range p.Players {
+		if player.IsBot() && !player.HasLeft && player.Money <= 0 {
+			p.Rebuy(player, p.CashGame.MaxBuyIn)
+		}
+	}
+}
+
+// countReadyPlayers は次のハンドに参加できるプレイヤーの人数を返す
+func (p *Poker) countReadyPlayers() (result int) {
+	for _, player := range p.Players {
+		if !player.IsSittingOut && !player.HasLeft && player.Money > 0 {
+			result++
+		}
+	}
+	return result
+}
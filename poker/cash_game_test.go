@@ -0,0 +1,39 @@
+package poker
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestJoinDuplicateName(t *testing.T) {
+	p := newTestPoker(t, 100, 50, 3000, 3000, 3000)
+	cashGame, err := NewCashGame(500, 3000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.CashGame = cashGame
+	p.IsHandFinished = true
+
+	name := p.Players[1].Name
+	if _, err := p.Join(Seat{Name: name, Money: 2000, Controller: Bot}); !errors.Is(err, ErrDuplicateName) {
+		t.Fatalf("Join with a seated name: err = %v, want ErrDuplicateName", err)
+	}
+	if _, err := p.Join(Seat{Money: 2000, Controller: Bot}); !errors.Is(err, ErrNoPlayerName) {
+		t.Fatalf("Join without a name: err = %v, want ErrNoPlayerName", err)
+	}
+
+	// 席を離れたプレイヤーは同じ名前で戻れる
+	if err := p.Leave(p.Players[1]); err != nil {
+		t.Fatal(err)
+	}
+	player, err := p.Join(Seat{Name: name, Money: 2000, Controller: Bot})
+	if err != nil {
+		t.Fatalf("Join after Leave: %v", err)
+	}
+	if player.Name != name || player.Money != 2000 {
+		t.Errorf("joined player = %s %d, want %s 2000", player.Name, player.Money, name)
+	}
+	if len(p.Players) != 3 {
+		t.Errorf("players = %d, want 3 (the left seat is reused)", len(p.Players))
+	}
+}
@@ -0,0 +1,370 @@
+package poker
+
+import (
+	"fmt"
+	"go_poker/card"
+	"go_poker/hand"
+	"go_poker/i18n"
+	"strings"
+	"time"
+)
+
+// Event はゲームの進行をリスナーに伝えるイベント
+// Stringはそのまま画面やログに表示できる文言を返す
+type Event interface {
+	fmt.Stringer
+	Header() *EventHeader
+}
+
+// EventHeader はイベントの発生時刻と、ハンドの中での通し番号
+// ハンドの外で起きたイベントの通し番号は0になる
+type EventHeader struct {
+	Sequence int
+	Time     time.Time
+}
+
+func (h *EventHeader) Header() *EventHeader {
+	return h
+}
+
+// HandSeat はハンド開始時に参加しているプレイヤーの座席とスタック
+type HandSeat struct {
+	Seat     int
+	Name     string
+	Position Position
+	Stack    int
+}
+
+// HandStarted は新しいハンドが始まったことを表す
+// ボタンとブラインドの座席、ブラインド額と、カードを配られるプレイヤーのブラインド支払い前のスタックを持つ
+// HandID はセッションやサイトをまたいで重複しないハンドの番号
+// SmallBetとBigBetはフィックスドリミットでのベット単位
+type HandStarted struct {
+	EventHeader
+	HandNumber     int
+	HandID         int64
+	Structure      BettingStructure
+	SmallBlind     int
+	BigBlind       int
+	Ante           int
+	SmallBet       int
+	BigBet         int
+	Button         int
+	SmallBlindSeat int
+	BigBlindSeat   int
+	TableSize      int
+	Seats          []HandSeat
+}
+
+// BlindType は強制ベットの種類
+type BlindType int
+
+const (
+	SmallBlindPost BlindType = iota + 1
+	BigBlindPost
+	AntePost
+	StraddlePost
+)
+
+func (b BlindType) String() string {
+	switch b {
+	case SmallBlindPost:
+		return "small blind"
+	case BigBlindPost:
+		return "big blind"
+	case AntePost:
+		return "ante"
+	case StraddlePost:
+		return "straddle"
+	default:
+		return "Unknown"
+	}
+}
+
+// BlindPosted はプレイヤーがブラインド、アンテ、ストラドルを支払ったことを表す
+// アンテはデッドマネーで、ストリートのベット額には含まれない
+type BlindPosted struct {
+	EventHeader
+	Seat   int
+	Name   string
+	Type   BlindType
+	Amount int
+}
+
+// HoleCardsDealt はプレイヤーにホールカードが配られたことを表す
+type HoleCardsDealt struct {
+	EventHeader
+	Seat  int
+	Name  string
+	Cards []card.Card
+}
+
+// BlindLevelRaised はブラインドが次のレベルに上がったことを表す
+type BlindLevelRaised struct {
+	EventHeader
+	Level  int
+	Blinds BlindLevel
+}
+
+// TurnStarted はプレイヤーの手番になったことを表す
+type TurnStarted struct {
+	EventHeader
+	Seat int
+	Name string
+}
+
+// ActionTaken はプレイヤーがアクションしたことを表す
+// Amountはアクション後のそのストリートでのベット額
+type ActionTaken struct {
+	EventHeader
+	Seat   int
+	Name   string
+	Action Action
+	Amount int
+}
+
+// StreetDealt は次のストリートに進み、ボードにカードが配られたことを表す
+// Boardはボードを複数回配るときの番号で、2つ目以降のボードは最初のボードのStreetより前のカードを共有する
+// Streetは配られた最初のカードのストリートで、2つ目以降のボードでは残りのカードをまとめて配ってもよい
+type StreetDealt struct {
+	EventHeader
+	Board  int
+	Street Street
+	Cards  []card.Card
+}
+
+// ShowdownRevealed はショーダウンでプレイヤーのホールカードと手役が公開されたことを表す
+type ShowdownRevealed struct {
+	EventHeader
+	Seat  int
+	Name  string
+	Cards []card.Card
+	Point hand.HandPoint
+}
+
+// HandMucked はショーダウンでプレイヤーが負けている手を見せずに捨てたことを表す
+type HandMucked struct {
+	EventHeader
+	Seat int
+	Name string
+}
+
+// CardsShown は全員がフォールドしてポットを獲得したプレイヤーが、ハンドの終了後にホールカードを見せたことを表す
+// ハンドの結果には影響せず、HandEndedより後にそのハンドのログへ記録する
+type CardsShown struct {
+	EventHeader
+	Seat  int
+	Name  string
+	Cards []card.Card
+	Point hand.HandPoint
+}
+
+// BetReturned は誰にもコールされなかったベットがプレイヤーに戻されたことを表す
+// ベッティングラウンドを閉じたときに記録され、戻した額はポットにも獲得額にも含めない
+type BetReturned struct {
+	EventHeader
+	Seat   int
+	Name   string
+	Amount int
+}
+
+// PotShare はポットを獲得したプレイヤーと獲得額
+type PotShare struct {
+	Seat   int
+	Name   string
+	Amount int
+}
+
+// RunItOffered は全員がオールインし、ハンドに残った全員に残りのボードを配る回数を尋ねていることを表す
+type RunItOffered struct {
+	EventHeader
+	MaxRuns int
+}
+
+// RunItAgreed は全員がオールインし、残りのボードを複数回配ることになったことを表す
+type RunItAgreed struct {
+	EventHeader
+	Runs int
+}
+
+// PotAwarded はポットが勝者に分配されたことを表す
+// ボードを複数回配った場合は、ポットをボードの数で等分した額をボードごとに分配し、Boardはそのボードの番号になる
+type PotAwarded struct {
+	EventHeader
+	Board    int
+	PotIndex int
+	Amount   int
+	Shares   []PotShare
+}
+
+// RabbitHunted はフォールドで終わったハンドで、次に配られるはずだったボードのカードを公開したことを表す
+// ハンドの結果には影響せず、HandEndedより後にそのハンドのログへ記録する
+type RabbitHunted struct {
+	EventHeader
+	Cards []card.Card
+}
+
+// PlayerEliminated はトーナメントでプレイヤーが敗退したことを表す
+type PlayerEliminated struct {
+	EventHeader
+	Player *Player
+	Place  int
+}
+
+// HandEnded はハンドが終了したことを表す
+type HandEnded struct {
+	EventHeader
+	HandNumber int
+	IsGameOver bool
+}
+
+// GameEnded はチップを持つプレイヤーが1人になり、ゲームが終了したことを表す
+type GameEnded struct {
+	EventHeader
+	HandCount  int
+	Players    []*Player
+	Tournament *Tournament
+}
+
+// SeatChange はキャッシュゲームでのハンドの間の座席の変化
+type SeatChange int
+
+const (
+	Rebought SeatChange = iota + 1
+	ToppedUp
+	SatOut
+	SatIn
+	Left
+	Joined
+)
+
+// SeatChanged はキャッシュゲームでプレイヤーの座席やスタックが変化したことを表す
+type SeatChanged struct {
+	EventHeader
+	Player *Player
+	Change SeatChange
+	Amount int
+	Seat   int
+}
+
+func (e HandStarted) String() string {
+	return i18n.T(msgHandStarted, e.HandNumber)
+}
+
+func (e BlindPosted) String() string {
+	if e.Type == StraddlePost {
+		return i18n.T(msgStraddlePosted, e.Name, e.Amount)
+	}
+	return i18n.T(msgBlindPosted, e.Name, e.Type, e.Amount)
+}
+
+func (e HoleCardsDealt) String() string {
+	return i18n.T(msgHoleCardsDealt, e.Name)
+}
+
+func (e BlindLevelRaised) String() string {
+	return i18n.T(msgBlindLevelRaised, e.Level+1, e.Blinds)
+}
+
+func (e TurnStarted) String() string {
+	return i18n.T(msgTurnStarted, e.Name)
+}
+
+func (e ActionTaken) String() string {
+	return i18n.T(msgActionTaken, e.Name, e.Action.Type)
+}
+
+func (e StreetDealt) String() string {
+	if e.Street == StreetFlop {
+		return i18n.T(msgFlopDealt)
+	}
+	return i18n.T(msgBoardDealt)
+}
+
+func (e ShowdownRevealed) String() string {
+	return i18n.T(msgShowdownRevealed, e.Name, e.Point)
+}
+
+func (e HandMucked) String() string {
+	return i18n.T(msgHandMucked, e.Name)
+}
+
+func (e CardsShown) String() string {
+	return i18n.T(msgCardsShown, e.Name, formatCards(e.Cards))
+}
+
+func (e BetReturned) String() string {
+	return i18n.T(msgBetReturned, e.Name, e.Amount)
+}
+
+func (e RunItOffered) String() string {
+	return i18n.T(msgRunItOffered, e.MaxRuns)
+}
+
+func (e RunItAgreed) String() string {
+	return i18n.T(msgRunItAgreed, e.Runs)
+}
+
+func (e PotAwarded) String() string {
+	var lines []string
+	for _, share := range e.Shares {
+		text := i18n.T(msgPotWon, share.Name, potName(e.PotIndex))
+		if len(e.Shares) > 1 {
+			text = i18n.T(msgPotSplit, share.Name, potName(e.PotIndex))
+		}
+		if e.Board > 0 {
+			text = i18n.T(msgOnBoard, e.Board+1, text)
+		}
+		lines = append(lines, text, i18n.T(msgPotShare, share.Amount))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (e RabbitHunted) String() string {
+	return i18n.T(msgRabbitHunted, formatCards(e.Cards))
+}
+
+func (e PlayerEliminated) String() string {
+	return i18n.T(msgPlayerEliminated, e.Player.Name, e.Place)
+}
+
+func (e HandEnded) String() string {
+	return i18n.T(msgHandEnded, e.HandNumber)
+}
+
+func (e GameEnded) String() string {
+	var lines []string
+	for _, player := range e.Players {
+		if player.Money > 0 {
+			lines = append(lines, i18n.T(msgWonAllChips, player.Name))
+		}
+	}
+	lines = append(lines, i18n.T(msgGameEnded, e.HandCount))
+	if e.Tournament != nil {
+		lines = append(lines, strings.TrimSuffix(e.Tournament.Report(e.Players), "\n"))
+		return strings.Join(lines, "\n")
+	}
+	for _, player := range e.Players {
+		lines = append(lines, fmt.Sprintf("%s: %s", player.Name, player.GetNetResultString()))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (e SeatChanged) String() string {
+	switch e.Change {
+	case Rebought:
+		return i18n.T(msgRebought, e.Player.Name, e.Amount)
+	case ToppedUp:
+		return i18n.T(msgToppedUp, e.Player.Name, e.Amount)
+	case SatOut:
+		return i18n.T(msgSatOut, e.Player.Name)
+	case SatIn:
+		return i18n.T(msgSatIn, e.Player.Name)
+	case Left:
+		return i18n.T(msgLeft, e.Player.Name, e.Amount, e.Player.GetNetResultString())
+	case Joined:
+		return i18n.T(msgJoined, e.Player.Name, e.Seat+1)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,113 @@
+package poker
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// HandHistoryFormat はハンド履歴のファイル形式
+type HandHistoryFormat int
+
+const (
+	PokerStarsFormat HandHistoryFormat = iota + 1
+	OpenHandHistoryFormat
+	PHHFormat
+)
+
+func (f HandHistoryFormat) String() string {
+	switch f {
+	case PokerStarsFormat:
+		return "PokerStars"
+	case OpenHandHistoryFormat:
+		return "Open Hand History"
+	case PHHFormat:
+		return "PHH"
+	default:
+		return "Unknown"
+	}
+}
+
+// HandHistoryFormatFor はファイルの拡張子からハンド履歴の形式を返す
+// .jsonと.ohhはOpen Hand History、.phhと.phhsはPHH、それ以外はPokerStars形式とする
+func HandHistoryFormatFor(path string) HandHistoryFormat {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".ohh":
+		return OpenHandHistoryFormat
+	case ".phh", ".phhs":
+		return PHHFormat
+	default:
+		return PokerStarsFormat
+	}
+}
+
+// HandHistoryWriter は終了したハンドの履歴を指定した形式で書き出すリスナー
+// ハンドの終了後に行うラビットハントも記録するため、ハンドは次のハンドが始まるか、Flushしたときに書き出す
+type HandHistoryWriter struct {
+	w      io.Writer
+	format HandHistoryFormat
+	hero   string
+	events []Event
+}
+
+// NewHandHistoryWriter はハンドの履歴を指定した形式で書き出すリスナーを作成する
+// どの形式でもheroのホールカードとショーダウンで見せたカードだけを書き出し、heroが空なら全員のホールカードを書き出す
+func NewHandHistoryWriter(w io.Writer, format HandHistoryFormat, hero string) *HandHistoryWriter {
+	return &HandHistoryWriter{w: w, format: format, hero: hero}
+}
+
+func (h *HandHistoryWriter) OnEvent(e Event) {
+	if _, ok := e.(*HandStarted); ok {
+		h.Flush()
+		h.events = nil
+	}
+	if e.Header().Sequence == 0 {
+		return
+	}
+	h.events = append(h.events, e)
+}
+
+// Flush は終了したハンドのうち、まだ書き出していないハンドを書き出す
+// 進行中のハンドは書き出さない
+func (h *HandHistoryWriter) Flush() error {
+	var ended *HandEnded
+	for _, e := range h.events {
+		if e, ok := e.(*HandEnded); ok {
+			ended = e
+		}
+	}
+	if ended == nil {
+		return nil
+	}
+	events := h.events
+	h.events = nil
+	var err error
+	switch h.format {
+	case OpenHandHistoryFormat:
+		err = WriteOpenHandHistory(h.w, events, h.hero)
+	case PHHFormat:
+		// 複数のハンドを1つのファイルに書き出すため、ハンドごとに表を分ける
+		fmt.Fprintf(h.w, "[%d]\n", ended.HandNumber)
+		err = WritePHHHand(h.w, events, h.hero)
+	default:
+		err = WritePokerStarsHand(h.w, events, h.hero)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(h.w, "\n\n")
+	return err
+}
+
+// ReadHandHistories は指定した形式のハンド履歴を読み込み、ハンドごとのイベントに変換する
+func ReadHandHistories(r io.Reader, format HandHistoryFormat) ([]*HandLog, error) {
+	switch format {
+	case OpenHandHistoryFormat:
+		return ReadOpenHandHistories(r)
+	case PHHFormat:
+		return ReadPHHHands(r)
+	default:
+		return ReadPokerStarsHands(r)
+	}
+}
@@ -0,0 +1,409 @@
+package poker
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// writeHistory は終了したハンドを指定した形式で書き出す
+func writeHistory(t *testing.T, p *Poker, format HandHistoryFormat) string {
+	t.Helper()
+	var b strings.Builder
+	w := NewHandHistoryWriter(&b, format, "")
+	for _, e := range p.CurrentHandLog().Events {
+		w.OnEvent(e)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	return b.String()
+}
+
+// readState は書き出したハンド履歴を読み込み、1つ目のハンドの最終状態を返す
+func readState(t *testing.T, text string, format HandHistoryFormat) *HandState {
+	t.Helper()
+	logs, err := ReadHandHistories(strings.NewReader(text), format)
+	if err != nil {
+		t.Fatalf("%s: %v\n%s", format, err, text)
+	}
+	if len(logs) != 1 {
+		t.Fatalf("%s: %d hands, want 1", format, len(logs))
+	}
+	state, err := logs[0].State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return state
+}
+
+// compareSeats は読み込んだハンドの各座席の結果が、エンジンの記録と一致するかを確かめる
+func compareSeats(t *testing.T, format HandHistoryFormat, got, want *HandState) {
+	t.Helper()
+	if len(got.Seats) != len(want.Seats) {
+		t.Fatalf("%s: seats = %d, want %d", format, len(got.Seats), len(want.Seats))
+	}
+	for i, w := range want.Seats {
+		g := got.Seats[i]
+		if g.Name != w.Name || g.Position != w.Position || g.Stack != w.Stack || g.TotalBet != w.TotalBet || g.Won != w.Won || g.IsFolded != w.IsFolded || g.IsRevealed != w.IsRevealed {
+			t.Errorf("%s: seat %d = %+v, want %+v", format, i, *g, *w)
+		}
+	}
+	if len(got.Boards) != len(want.Boards) {
+		t.Fatalf("%s: boards = %d, want %d", format, len(got.Boards), len(want.Boards))
+	}
+	for i := range want.Boards {
+		if formatCards(got.Boards[i]) != formatCards(want.Boards[i]) {
+			t.Errorf("%s: board %d = %s, want %s", format, i, formatCards(got.Boards[i]), formatCards(want.Boards[i]))
+		}
+	}
+}
+
+// playHistories はBotだけのゲームを最大handsハンド進め、指定した形式ごとに書き出したハンド履歴を返す
+func playHistories(t *testing.T, p *Poker, hands int, formats ...HandHistoryFormat) []string {
+	t.Helper()
+	builders := make([]*strings.Builder, len(formats))
+	writers := make([]*HandHistoryWriter, len(formats))
+	for i, format := range formats {
+		builders[i] = &strings.Builder{}
+		writers[i] = NewHandHistoryWriter(builders[i], format, "")
+		p.AddListener(writers[i])
+	}
+	for _, player := range p.Players {
+		player.Controller = Bot
+	}
+	p.StartHand()
+	for i := 1; i < hands && !p.IsGameOver(); i++ {
+		if err := p.NextHand(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	texts := make([]string, len(formats))
+	for i, w := range writers {
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		texts[i] = builders[i].String()
+	}
+	return texts
+}
+
+// writeEvents はイベントを1つのハンドとして指定した形式で書き出す
+func writeEvents(t *testing.T, events []Event, format HandHistoryFormat) string {
+	t.Helper()
+	var b strings.Builder
+	var err error
+	switch format {
+	case OpenHandHistoryFormat:
+		err = WriteOpenHandHistory(&b, events, "")
+	case PHHFormat:
+		err = WritePHHHand(&b, events, "")
+	default:
+		err = WritePokerStarsHand(&b, events, "")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b.String()
+}
+
+// assertRoundTrip は書き出したハンド履歴を読み込み、エンジンの記録と同じ結果になり、同じ履歴に書き戻せるかを確かめる
+func assertRoundTrip(t *testing.T, p *Poker, text string, format HandHistoryFormat) {
+	t.Helper()
+	logs, err := ReadHandHistories(strings.NewReader(text), format)
+	if err != nil {
+		t.Fatalf("%s: %v", format, err)
+	}
+	if len(logs) != len(p.HandLogs) {
+		t.Fatalf("%s: %d hands, want %d", format, len(logs), len(p.HandLogs))
+	}
+	for i, log := range logs {
+		got, err := log.State()
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := p.HandLogs[i].State()
+		if err != nil {
+			t.Fatal(err)
+		}
+		compareSeats(t, format, got, want)
+
+		// PokerStars形式の1行目には書き出した時刻が入るため比べない
+		rewritten := writeEvents(t, log.Events, format)
+		original := writeEvents(t, p.HandLogs[i].Events, format)
+		if format == PokerStarsFormat {
+			rewritten = strings.SplitN(rewritten, "\n", 2)[1]
+			original = strings.SplitN(original, "\n", 2)[1]
+		}
+		if rewritten != original {
+			t.Fatalf("%s: hand %d is rewritten differently\n%s\n----\n%s", format, i+1, original, rewritten)
+		}
+	}
+}
+
+func TestPokerStarsUncalledBet(t *testing.T) {
+	p := newTestPoker(t, 100, 50, 3000, 3000, 3000)
+	p.StartHand()
+	raiser := p.getCurrentPlayer().Name
+	act(t, p, Action{Type: Raise, Bet: 1000})
+	act(t, p, Action{Type: Fold})
+	act(t, p, Action{Type: Fold})
+
+	text := writeHistory(t, p, PokerStarsFormat)
+	for _, line := range []string{
+		"Uncalled bet ($900) returned to " + raiser + "\n",
+		raiser + " collected $250 from pot\n",
+		"Total pot $250 ",
+	} {
+		if !strings.Contains(text, line) {
+			t.Errorf("missing %q in\n%s", line, text)
+		}
+	}
+
+	want, err := p.CurrentHandLog().State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compareSeats(t, PokerStarsFormat, readState(t, text, PokerStarsFormat), want)
+}
+
+func TestPokerStarsRoundTrip(t *testing.T) {
+	for n := 2; n <= 9; n++ {
+		p := newTestPoker(t, 100, 50, equalStacks(n, 1000)...)
+		p.Ante = 10
+		p.Straddle = UTGStraddle
+		p.MaxRuns = 2
+		texts := playHistories(t, p, 30, PokerStarsFormat)
+		assertRoundTrip(t, p, texts[0], PokerStarsFormat)
+	}
+}
+
+func TestReadPokerStarsHandsError(t *testing.T) {
+	text := "PokerStars Hand #1: Hold'em No Limit ($1/$2) - 2020/01/01 10:00:00 ET\n" +
+		"Table 'x' 6-max Seat #1 is the button\n" +
+		"Seat 1: A ($100 in chips)\n" +
+		"Seat 2: B ($100 in chips)\n" +
+		"A: posts small blind $1\n" +
+		"B: posts big blind $2\n" +
+		"*** HOLE CARDS ***\n" +
+		"Dealt to A [Ah Zz]\n"
+	_, err := ReadPokerStarsHands(strings.NewReader(text))
+	var parseErrs ParseErrors
+	if !errors.As(err, &parseErrs) || len(parseErrs) != 1 {
+		t.Fatalf("error = %v, want one ParseError", err)
+	}
+	if parseErrs[0].Line != 8 || parseErrs[0].Text != "Dealt to A [Ah Zz]" {
+		t.Errorf("error at line %d %q, want line 8", parseErrs[0].Line, parseErrs[0].Text)
+	}
+}
+
+func TestOpenFormatsRoundTrip(t *testing.T) {
+	structures := []BettingStructure{NoLimit, PotLimit, FixedLimit}
+	for _, format := range []HandHistoryFormat{OpenHandHistoryFormat, PHHFormat} {
+		for n := 2; n <= 9; n++ {
+			for _, bigBlindAnte := range []bool{false, true} {
+				p := newTestPoker(t, 100, 50, equalStacks(n, 1000)...)
+				p.Ante = 10
+				p.BigBlindAnte = bigBlindAnte
+				p.Straddle = UTGStraddle
+				p.Structure = structures[n%len(structures)]
+				p.MaxRuns = MaxRunouts
+				texts := playHistories(t, p, 30, format)
+				assertRoundTrip(t, p, texts[0], format)
+			}
+		}
+	}
+}
+
+func TestPHHUncalledBet(t *testing.T) {
+	text := `variant = 'NT'
+antes = [0, 0, 0]
+blinds_or_straddles = [50, 100, 0]
+min_bet = 100
+starting_stacks = [1000, 1000, 1000]
+actions = [
+  'd dh p1 ????',
+  'd dh p2 ????',
+  'd dh p3 ????',
+  'p3 cbr 300',
+  'p1 f',
+  'p2 cc',
+  'd db Jc3d5c',
+  'p2 cbr 400',
+  'p3 f',
+]
+players = ['A', 'B', 'C']
+finishing_stacks = [950, 1350, 700]
+`
+	for _, withStacks := range []bool{true, false} {
+		input := text
+		if !withStacks {
+			input = strings.Replace(text, "finishing_stacks = [950, 1350, 700]\n", "", 1)
+		}
+		logs, err := ReadPHHHands(strings.NewReader(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var returned *BetReturned
+		for _, e := range logs[0].Events {
+			if e, ok := e.(*BetReturned); ok {
+				returned = e
+			}
+		}
+		if returned == nil || returned.Name != "B" || returned.Amount != 400 {
+			t.Fatalf("BetReturned = %+v, want 400 to B", returned)
+		}
+		state, err := logs[0].State()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, want := range []struct{ stack, won int }{{950, 0}, {1350, 650}, {700, 0}} {
+			seat := state.Seats[i]
+			if seat.Stack != want.stack || seat.Won != want.won {
+				t.Errorf("finishing_stacks %v: %s stack = %d won = %d, want %d and %d", withStacks, seat.Name, seat.Stack, seat.Won, want.stack, want.won)
+			}
+		}
+	}
+}
+
+func TestPHHShowdownWithoutFinishingStacks(t *testing.T) {
+	text := `variant = 'NT'
+antes = [0, 0, 0]
+blinds_or_straddles = [50, 100, 0]
+min_bet = 100
+starting_stacks = [500, 1000, 1000]
+actions = [
+  'd dh p1 AsAh',
+  'd dh p2 KsKh',
+  'd dh p3 QsQh',
+  'p3 cbr 1000',
+  'p1 cc',
+  'p2 cc',
+  'd db 2c7d9h',
+  'd db Jc',
+  'd db 3s',
+  'p1 sm AsAh',
+  'p2 sm KsKh',
+  'p3 sm QsQh',
+]
+players = ['A', 'B', 'C']
+`
+	// メインポットはAのAA、BとCだけのサイドポットはBのKKが獲得する
+	want := []struct{ stack, won int }{{1500, 1500}, {1000, 1000}, {0, 0}}
+	for _, withStacks := range []bool{false, true} {
+		input := text
+		if withStacks {
+			input += "finishing_stacks = [1500, 1000, 0]\n"
+		}
+		state := readState(t, input, PHHFormat)
+		for i, w := range want {
+			seat := state.Seats[i]
+			if seat.Stack != w.stack || seat.Won != w.won {
+				t.Errorf("finishing_stacks %v: %s stack = %d won = %d, want %d and %d", withStacks, seat.Name, seat.Stack, seat.Won, w.stack, w.won)
+			}
+		}
+	}
+
+	// 残ったプレイヤーのホールカードが分からなければ、分配を捨てずにエラーにする
+	unknown := strings.Replace(strings.Replace(text, "'d dh p2 KsKh'", "'d dh p2 ????'", 1), "  'p2 sm KsKh',\n", "", 1)
+	logs, err := ReadPHHHands(strings.NewReader(unknown))
+	var errs ParseErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || len(logs) != 0 {
+		t.Fatalf("ReadPHHHands = %d hands, %v, want a ParseError", len(logs), err)
+	}
+}
+
+func TestOpenFormatsMultipleBoards(t *testing.T) {
+	started := &HandStarted{
+		HandNumber: 1, HandID: 7, SmallBlind: 50, BigBlind: 100,
+		Button: 0, SmallBlindSeat: 1, BigBlindSeat: 2, TableSize: 3,
+		Seats: []HandSeat{
+			{Seat: 0, Name: "A", Position: BTN, Stack: 1000},
+			{Seat: 1, Name: "B", Position: SB, Stack: 500},
+			{Seat: 2, Name: "C", Position: BB, Stack: 1000},
+		},
+	}
+	events := []Event{
+		started,
+		&BlindPosted{Seat: 1, Name: "B", Type: SmallBlindPost, Amount: 50},
+		&BlindPosted{Seat: 2, Name: "C", Type: BigBlindPost, Amount: 100},
+		&HoleCardsDealt{Seat: 0, Name: "A", Cards: parseCards(t, "As Ks")},
+		&HoleCardsDealt{Seat: 1, Name: "B", Cards: parseCards(t, "Qh Qd")},
+		&HoleCardsDealt{Seat: 2, Name: "C", Cards: parseCards(t, "2c 7d")},
+		&ActionTaken{Seat: 0, Name: "A", Action: Action{Type: Raise, Bet: 300}, Amount: 300},
+		&ActionTaken{Seat: 1, Name: "B", Action: Action{Type: AllIn, Bet: 500}, Amount: 500},
+		&ActionTaken{Seat: 2, Name: "C", Action: Action{Type: Fold}, Amount: 100},
+		&ActionTaken{Seat: 0, Name: "A", Action: Action{Type: Call}, Amount: 500},
+		&RunItAgreed{Runs: 2},
+		&StreetDealt{Street: StreetFlop, Cards: parseCards(t, "2h 3h 9c")},
+		&StreetDealt{Street: StreetTurn, Cards: parseCards(t, "Jd")},
+		&StreetDealt{Street: StreetRiver, Cards: parseCards(t, "4s")},
+		&StreetDealt{Board: 1, Street: StreetTurn, Cards: parseCards(t, "Ad 5c")},
+		&ShowdownRevealed{Seat: 0, Name: "A", Cards: parseCards(t, "As Ks")},
+		&ShowdownRevealed{Seat: 1, Name: "B", Cards: parseCards(t, "Qh Qd")},
+		&PotAwarded{PotIndex: 0, Amount: 550, Shares: []PotShare{{Seat: 1, Name: "B", Amount: 550}}},
+		&PotAwarded{Board: 1, PotIndex: 0, Amount: 550, Shares: []PotShare{{Seat: 0, Name: "A", Amount: 550}}},
+		&HandEnded{HandNumber: 1},
+	}
+	for i, e := range events {
+		e.Header().Sequence = i + 1
+	}
+	want, err := RebuildHand(events)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := formatCards(want.Boards[1]); got != "2h 3h 9c Ad 5c" {
+		t.Fatalf("second board = %s", got)
+	}
+	for _, format := range []HandHistoryFormat{OpenHandHistoryFormat, PHHFormat} {
+		text := writeEvents(t, events, format)
+		compareSeats(t, format, readState(t, text, format), want)
+	}
+}
+
+func TestHistoryHidesUnshownCards(t *testing.T) {
+	p := newTestPoker(t, 100, 50, 3000, 3000, 3000)
+	p.StartHand()
+	hero := p.getCurrentPlayer()
+	act(t, p, Action{Type: Raise, Bet: 300})
+	folded := p.getCurrentPlayer()
+	act(t, p, Action{Type: Fold})
+	caller := p.getCurrentPlayer()
+	caller.MucksLosing = true
+	act(t, p, Action{Type: Call})
+	for !p.IsHandFinished {
+		act(t, p, Action{Type: Check})
+	}
+	want, err := p.CurrentHandLog().State()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, format := range []HandHistoryFormat{PokerStarsFormat, OpenHandHistoryFormat, PHHFormat} {
+		var b strings.Builder
+		w := NewHandHistoryWriter(&b, format, hero.Name)
+		for _, e := range p.CurrentHandLog().Events {
+			w.OnEvent(e)
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		got := readState(t, b.String(), format)
+		for i, seat := range got.Seats {
+			switch name := p.Players[i].Name; {
+			case name == hero.Name:
+				if len(seat.HoleCards) == 0 {
+					t.Errorf("%s: hero's hole cards were not written", format)
+				}
+			case name == folded.Name || want.Seats[i].IsMucked:
+				if len(seat.HoleCards) != 0 {
+					t.Errorf("%s: %s's hidden cards were written: %s", format, name, formatCards(seat.HoleCards))
+				}
+			case want.Seats[i].IsRevealed:
+				if formatCards(seat.HoleCards) != formatCards(want.Seats[i].HoleCards) {
+					t.Errorf("%s: %s's shown cards = %s, want %s", format, name, formatCards(seat.HoleCards), formatCards(want.Seats[i].HoleCards))
+				}
+			}
+		}
+	}
+}
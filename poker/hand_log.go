@@ -0,0 +1,312 @@
+package poker
+
+import (
+	"go_poker/card"
+	"go_poker/hand"
+	"go_poker/i18n"
+	"time"
+)
+
+// HandLog は1ハンドの間に起きたイベントを起きた順に記録する
+// HandStartedからHandEndedまでのイベントだけで、ハンドの状態を作り直せる
+type HandLog struct {
+	HandNumber int
+	Events     []Event
+	IsComplete bool
+}
+
+// State はハンドログのイベントから、ハンド終了時(進行中なら現在)の状態を作り直す
+func (l *HandLog) State() (*HandState, error) {
+	return RebuildHand(l.Events)
+}
+
+// CurrentHandLog は進行中または直前のハンドのログを返す
+func (p *Poker) CurrentHandLog() *HandLog {
+	if len(p.HandLogs) == 0 {
+		return nil
+	}
+	return p.HandLogs[len(p.HandLogs)-1]
+}
+
+// recordEvent はイベントに発生時刻を付け、ハンドの進行中であれば通し番号を付けてハンドログに記録する
+func (p *Poker) recordEvent(e Event) {
+	header := e.Header()
+	header.Time = time.Now()
+	if started, ok := e.(*HandStarted); ok {
+		p.HandLogs = append(p.HandLogs, &HandLog{HandNumber: started.HandNumber})
+	}
+
+	log := p.CurrentHandLog()
+	if log == nil || (log.IsComplete && !isAfterHandEvent(e)) {
+		return
+	}
+	header.Sequence = len(log.Events) + 1
+	log.Events = append(log.Events, e)
+	if _, ok := e.(*HandEnded); ok {
+		log.IsComplete = true
+	}
+}
+
+// isAfterHandEvent はハンドの終了後に起き、そのハンドのログに記録するイベントかを返す
+func isAfterHandEvent(e Event) bool {
+	switch e.(type) {
+	case *RabbitHunted, *CardsShown:
+		return true
+	default:
+		return false
+	}
+}
+
+// HandState はイベントから作り直したハンドの状態
+// Boardは最初のボードで、Boardsはボードを複数回配った場合も含めた全てのボード
+// Huntedはラビットハントで公開した、次に配られるはずだったボードのカード
+type HandState struct {
+	HandNumber int
+	Button     int
+	SmallBlind int
+	BigBlind   int
+	Ante       int
+	Street     Street
+	Board      []card.Card
+	Boards     [][]card.Card
+	Hunted     []card.Card
+	Seats      []*SeatState
+	IsFinished bool
+}
+
+// SeatState はイベントから作り直したプレイヤーごとの状態
+type SeatState struct {
+	Seat       int
+	Name       string
+	Position   Position
+	Stack      int
+	Bet        int
+	TotalBet   int
+	AntePaid   int
+	HoleCards  []card.Card
+	LastAction Action
+	IsFolded   bool
+	IsRevealed bool
+	IsMucked   bool
+	Point      hand.HandPoint
+	Won        int
+}
+
+// RebuildHand はハンドのイベントを最初から順に適用して、ハンドの状態を作り直す
+// 最初のイベントはHandStartedでなければならない
+func RebuildHand(events []Event) (*HandState, error) {
+	if len(events) == 0 {
+		return nil, ErrNoEvents
+	}
+	started, ok := events[0].(*HandStarted)
+	if !ok {
+		return nil, ErrNotHandStarted
+	}
+	state := NewHandState(started)
+	for _, e := range events[1:] {
+		if err := state.Apply(e); err != nil {
+			return nil, err
+		}
+	}
+	return state, nil
+}
+
+// NewHandState はハンド開始時の状態を作成する
+func NewHandState(e *HandStarted) *HandState {
+	state := &HandState{
+		HandNumber: e.HandNumber,
+		Button:     e.Button,
+		SmallBlind: e.SmallBlind,
+		BigBlind:   e.BigBlind,
+		Ante:       e.Ante,
+		Street:     StreetPreFlop,
+	}
+	for _, seat := range e.Seats {
+		state.Seats = append(state.Seats, &SeatState{
+			Seat:     seat.Seat,
+			Name:     seat.Name,
+			Position: seat.Position,
+			Stack:    seat.Stack,
+		})
+	}
+	return state
+}
+
+// Apply はイベントを1つ適用して状態を進める。ハンドの状態に関係しないイベントは無視する
+func (s *HandState) Apply(e Event) error {
+	switch e := e.(type) {
+	case *BlindPosted:
+		seat, err := s.seat(e.Seat)
+		if err != nil {
+			return err
+		}
+		seat.Stack -= e.Amount
+		seat.TotalBet += e.Amount
+		if e.Type == AntePost {
+			seat.AntePaid += e.Amount
+		} else {
+			seat.Bet += e.Amount
+		}
+	case *HoleCardsDealt:
+		seat, err := s.seat(e.Seat)
+		if err != nil {
+			return err
+		}
+		seat.HoleCards = e.Cards
+	case *ActionTaken:
+		seat, err := s.seat(e.Seat)
+		if err != nil {
+			return err
+		}
+		seat.Stack -= e.Amount - seat.Bet
+		seat.TotalBet += e.Amount - seat.Bet
+		seat.Bet = e.Amount
+		seat.LastAction = e.Action
+		if e.Action.Type == Fold {
+			seat.IsFolded = true
+		}
+	case *StreetDealt:
+		if e.Board > 0 {
+			return s.dealBoard(e)
+		}
+		s.Street = e.Street
+		s.Board = append(s.Board, e.Cards...)
+		for _, seat := range s.Seats {
+			seat.Bet = 0
+			if !seat.IsFolded {
+				seat.LastAction = Action{}
+			}
+		}
+		if len(s.Boards) == 0 {
+			s.Boards = [][]card.Card{nil}
+		}
+		s.Boards[0] = s.Board
+	case *ShowdownRevealed:
+		seat, err := s.seat(e.Seat)
+		if err != nil {
+			return err
+		}
+		s.Street = StreetShowDown
+		seat.HoleCards = e.Cards
+		seat.IsRevealed = true
+		seat.Point = e.Point
+	case *HandMucked:
+		seat, err := s.seat(e.Seat)
+		if err != nil {
+			return err
+		}
+		s.Street = StreetShowDown
+		seat.IsMucked = true
+	case *CardsShown:
+		seat, err := s.seat(e.Seat)
+		if err != nil {
+			return err
+		}
+		seat.HoleCards = e.Cards
+		seat.IsRevealed = true
+		seat.Point = e.Point
+	case *BetReturned:
+		seat, err := s.seat(e.Seat)
+		if err != nil {
+			return err
+		}
+		seat.Stack += e.Amount
+		seat.Bet -= e.Amount
+		seat.TotalBet -= e.Amount
+	case *PotAwarded:
+		for _, share := range e.Shares {
+			seat, err := s.seat(share.Seat)
+			if err != nil {
+				return err
+			}
+			seat.Stack += share.Amount
+			seat.Won += share.Amount
+		}
+	case *RabbitHunted:
+		s.Hunted = append(s.Hunted, e.Cards...)
+	case *HandEnded:
+		s.IsFinished = true
+		for _, seat := range s.Seats {
+			seat.Bet = 0
+		}
+	}
+	return nil
+}
+
+// dealBoard は2つ目以降のボードにカードを配る
+// 新しいボードは、最初のボードのうち配り始めたストリートより前のカードを共有する
+func (s *HandState) dealBoard(e *StreetDealt) error {
+	for len(s.Boards) <= e.Board {
+		s.Boards = append(s.Boards, nil)
+	}
+	board := s.Boards[e.Board]
+	if len(board) == 0 {
+		shared := boardCardsBefore(e.Street)
+		if shared > len(s.Board) {
+			return i18n.Errorf(msgBoardNotDealt, e.Board+1, e.Street)
+		}
+		board = append([]card.Card(nil), s.Board[:shared]...)
+	}
+	s.Boards[e.Board] = append(board, e.Cards...)
+	return nil
+}
+
+// boardCardsBefore はストリートより前に配られるボードのカードの枚数を返す
+func boardCardsBefore(street Street) int {
+	switch street {
+	case StreetTurn:
+		return 3
+	case StreetRiver, StreetShowDown:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// Pot はまだ分配されていないポットの額を返す
+func (s *HandState) Pot() (result int) {
+	for _, seat := range s.Seats {
+		result += seat.TotalBet - seat.Won
+	}
+	if result < 0 {
+		return 0
+	}
+	return result
+}
+
+// uncalledBet はフォールドしていないプレイヤーの、誰にもコールされなかったベット額を返す
+func (s *HandState) uncalledBet() (*SeatState, int) {
+	bets := make([]int, len(s.Seats))
+	for i, seat := range s.Seats {
+		bets[i] = seat.TotalBet - seat.AntePaid
+	}
+	index, amount := uncalledBet(bets)
+	if index < 0 || s.Seats[index].IsFolded {
+		return nil, 0
+	}
+	return s.Seats[index], amount
+}
+
+// lastPlayer は他の全員がフォールドしていれば、残った1人を返す
+func (s *HandState) lastPlayer() *SeatState {
+	var result *SeatState
+	for _, seat := range s.Seats {
+		if seat.IsFolded {
+			continue
+		}
+		if result != nil {
+			return nil
+		}
+		result = seat
+	}
+	return result
+}
+
+func (s *HandState) seat(index int) (*SeatState, error) {
+	for _, seat := range s.Seats {
+		if seat.Seat == index {
+			return seat, nil
+		}
+	}
+	return nil, i18n.Errorf(msgSeatNotInHand, index+1)
+}
@@ -0,0 +1,257 @@
+package poker
+
+import (
+	"go_poker/card"
+	"go_poker/hand"
+	"go_poker/i18n"
+	"sort"
+)
+
+// handRecorder は他の形式のハンド履歴から、エンジンと同じイベントを組み立てる
+// 組み立てたイベントはその場でHandStateに適用し、ベット額やオールインの判定に使う
+// afterHandはラビットハントなど、HandEndedより後に記録するイベント
+type handRecorder struct {
+	started   *HandStarted
+	events    []Event
+	state     *HandState
+	afterHand []Event
+}
+
+// newHandRecorder は座席とブラインドの座席が決まったハンドの開始イベントから、イベントの組み立てを始める
+func newHandRecorder(started *HandStarted) *handRecorder {
+	assignPositions(started)
+	r := &handRecorder{started: started, state: NewHandState(started)}
+	r.add(started)
+	return r
+}
+
+func (r *handRecorder) add(e Event) error {
+	header := e.Header()
+	header.Sequence = len(r.events) + 1
+	header.Time = r.started.Time
+	r.events = append(r.events, e)
+	return r.state.Apply(e)
+}
+
+func (r *handRecorder) post(seat int, blindType BlindType, amount int) error {
+	s, err := r.state.seat(seat)
+	if err != nil {
+		return err
+	}
+	if amount > s.Stack {
+		return i18n.Errorf(msgPostOverStack, s.Name)
+	}
+	return r.add(&BlindPosted{Seat: seat, Name: s.Name, Type: blindType, Amount: amount})
+}
+
+func (r *handRecorder) dealHoleCards(seat int, cards []card.Card) error {
+	s, err := r.state.seat(seat)
+	if err != nil {
+		return err
+	}
+	return r.add(&HoleCardsDealt{Seat: seat, Name: s.Name, Cards: cards})
+}
+
+func (r *handRecorder) fold(seat int) error {
+	s, err := r.state.seat(seat)
+	if err != nil {
+		return err
+	}
+	return r.add(&ActionTaken{Seat: seat, Name: s.Name, Action: Action{Type: Fold}, Amount: s.Bet})
+}
+
+// checkOrCall はストリートの最大のベット額までコールし、ベット額が揃っていればチェックにする
+// スタックが足りなければ残りのスタックだけをコールする
+func (r *handRecorder) checkOrCall(seat int) error {
+	s, err := r.state.seat(seat)
+	if err != nil {
+		return err
+	}
+	to := r.state.maxBet()
+	if to > s.Bet+s.Stack {
+		to = s.Bet + s.Stack
+	}
+	action := Action{Type: Call}
+	if to <= s.Bet {
+		to = s.Bet
+		action.Type = Check
+	}
+	return r.add(&ActionTaken{Seat: seat, Name: s.Name, Action: action, Amount: to})
+}
+
+// raiseTo はストリートのベット額がtoになるようにベットまたはレイズする
+func (r *handRecorder) raiseTo(seat int, to int) error {
+	s, err := r.state.seat(seat)
+	if err != nil {
+		return err
+	}
+	switch {
+	case to <= s.Bet:
+		return i18n.Errorf(msgRaiseNotAbove, s.Name, to)
+	case to-s.Bet > s.Stack:
+		return i18n.Errorf(msgBetOverStack, s.Name)
+	}
+	action := Action{Type: Raise, Bet: to}
+	if to-s.Bet == s.Stack {
+		action.Type = AllIn
+	}
+	return r.add(&ActionTaken{Seat: seat, Name: s.Name, Action: action, Amount: to})
+}
+
+// returnUncalledBet は誰にもコールされなかったベットを戻す
+// 戻したことを書かない形式のために、ベッティングラウンドを閉じるたびにエンジンと同じ判定をする
+func (r *handRecorder) returnUncalledBet() error {
+	s, amount := r.state.uncalledBet()
+	if s == nil {
+		return nil
+	}
+	return r.add(&BetReturned{Seat: s.Seat, Name: s.Name, Amount: amount})
+}
+
+// dealBoard はボードにカードを配る
+func (r *handRecorder) dealBoard(board int, street Street, cards []card.Card) error {
+	if len(cards) == 0 {
+		return i18n.Errorf(msgNoBoardCards)
+	}
+	if err := r.returnUncalledBet(); err != nil {
+		return err
+	}
+	dealt := len(r.state.Board)
+	if board > 0 {
+		dealt = boardCardsBefore(street)
+		if board < len(r.state.Boards) {
+			dealt = len(r.state.Boards[board])
+		}
+	}
+	if dealt+len(cards) > 5 {
+		return i18n.Errorf(msgBoardOverFive)
+	}
+	return r.add(&StreetDealt{Board: board, Street: street, Cards: cards})
+}
+
+func (r *handRecorder) show(seat int, cards []card.Card) error {
+	s, err := r.state.seat(seat)
+	if err != nil {
+		return err
+	}
+	if err := r.returnUncalledBet(); err != nil {
+		return err
+	}
+	h := hand.Hand{Cards: cards}
+	h.Culc(r.state.Board)
+	return r.add(&ShowdownRevealed{Seat: seat, Name: s.Name, Cards: cards, Point: h.Point})
+}
+
+// muck はショーダウンで手を見せずに捨てたことを記録する。フォールドしたプレイヤーのマックは記録しない
+func (r *handRecorder) muck(seat int) error {
+	s, err := r.state.seat(seat)
+	if err != nil || s.IsFolded {
+		return err
+	}
+	if err := r.returnUncalledBet(); err != nil {
+		return err
+	}
+	return r.add(&HandMucked{Seat: seat, Name: s.Name})
+}
+
+// showAfterHand は全員がフォールドして勝ったプレイヤーが、ハンドの終了後に見せたホールカードを記録する
+func (r *handRecorder) showAfterHand(seat int, cards []card.Card) error {
+	s, err := r.state.seat(seat)
+	if err != nil {
+		return err
+	}
+	h := hand.Hand{Cards: cards}
+	h.Culc(r.state.Board)
+	r.afterHand = append(r.afterHand, &CardsShown{Seat: seat, Name: s.Name, Cards: cards, Point: h.Point})
+	return nil
+}
+
+// awardPots は分配が書かれていないハンドで、エンジンと同じ方法でポットを分配する
+// ショーダウンでは見せた手をボードで評価してメインポットとサイドポットの勝者を決め、見せなかったプレイヤーは獲得できない
+// 残ったプレイヤーのホールカードかボードが分からなければ分配できないため、エラーを返す
+func (r *handRecorder) awardPots() error {
+	if err := r.returnUncalledBet(); err != nil {
+		return err
+	}
+	game := &Poker{Button: r.started.Button, Flop: r.state.Board}
+	if len(r.state.Boards) > 1 {
+		game.Runouts = r.state.Boards[1:]
+	}
+	game.Players = make([]*Player, r.started.TableSize)
+	for i := range game.Players {
+		game.Players[i] = &Player{HasLeft: true}
+	}
+	var inHand []*SeatState
+	for _, s := range r.state.Seats {
+		player := &Player{Name: s.Name, Money: s.Stack, TotalBet: s.TotalBet, AntePaid: s.AntePaid}
+		if s.IsFolded || s.IsMucked {
+			player.CurrentAction = Action{Type: Fold}
+		} else {
+			inHand = append(inHand, s)
+			player.Hand = hand.Hand{Cards: s.HoleCards}
+		}
+		game.Players[s.Seat] = player
+	}
+	if len(inHand) > 1 {
+		for _, board := range game.Boards() {
+			if len(board) < 5 {
+				return i18n.Errorf(msgShowdownBoardIncomplete)
+			}
+		}
+		for _, s := range inHand {
+			if len(s.HoleCards) == 0 {
+				return i18n.Errorf(msgShowdownCardsUnknown, s.Name)
+			}
+			game.Players[s.Seat].Hand.Culc(r.state.Board)
+		}
+	}
+
+	var awards []Event
+	game.AddListener(ListenerFunc(func(e Event) {
+		awards = append(awards, e)
+	}))
+	game.awardPots()
+	for _, e := range awards {
+		if err := r.add(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *handRecorder) finish() *HandLog {
+	r.add(&HandEnded{HandNumber: r.started.HandNumber})
+	for _, e := range r.afterHand {
+		r.add(e)
+	}
+	return &HandLog{HandNumber: r.started.HandNumber, Events: r.events, IsComplete: true}
+}
+
+// streetForBoardCards はボードに配られているカードの枚数から、次に配るストリートを返す
+func streetForBoardCards(count int) (Street, bool) {
+	switch count {
+	case 0:
+		return StreetFlop, true
+	case 3:
+		return StreetTurn, true
+	case 4:
+		return StreetRiver, true
+	default:
+		return 0, false
+	}
+}
+
+// assignPositions はエンジンと同じく、ボタンとブラインドの座席を基準にポジションを割り当てる
+func assignPositions(started *HandStarted) {
+	seats := started.Seats
+	sort.Slice(seats, func(i, j int) bool {
+		return seats[i].Seat < seats[j].Seat
+	})
+	numbers := make([]int, len(seats))
+	for i, seat := range seats {
+		numbers[i] = seat.Seat
+	}
+	for i, position := range seatPositions(numbers, started.Button, started.SmallBlindSeat, started.BigBlindSeat) {
+		seats[i].Position = position
+	}
+}
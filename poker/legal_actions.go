@@ -0,0 +1,58 @@
+package poker
+
+// LegalActions はプレイヤーが現在選択できるアクションと、コールやレイズの額
+// CallAmountはコールで追加で支払う額で、所持金が足りなければ所持金すべてになる
+// MinRaiseToとMaxRaiseToはレイズ後のベット額として指定できる範囲で、所持金が最低レイズ額に満たなければ両方ともオールインの額になる
+type LegalActions struct {
+	Types      []ActionType
+	CallAmount int
+	MinRaiseTo int
+	MaxRaiseTo int
+}
+
+// Can は指定したアクションを選択できるかを返す
+func (l LegalActions) Can(actionType ActionType) bool {
+	for _, t := range l.Types {
+		if t == actionType {
+			return true
+		}
+	}
+	return false
+}
+
+// LegalActions はプレイヤーが現在選択できるアクションを返す
+// 手番でないプレイヤーや、ハンドが終了している場合は何も選択できない
+// Actionはここで選択できるアクションだけを受け付ける
+func (p *Poker) LegalActions(player *Player) LegalActions {
+	if p.IsHandFinished || p.IsChoosingRuns || player != p.getCurrentPlayer() || !player.canAction() {
+		return LegalActions{}
+	}
+
+	legal := LegalActions{Types: []ActionType{Fold}}
+	if diff := p.TurnBet - player.CurrentBet; diff > 0 {
+		legal.CallAmount = diff
+		if diff > player.Money {
+			legal.CallAmount = player.Money
+		}
+		legal.Types = append(legal.Types, Call)
+	} else {
+		legal.Types = append(legal.Types, Check)
+	}
+
+	allInBet := player.CurrentBet + player.Money
+	maxRaiseBet := p.MaxRaiseBet(player)
+	canRaise := p.canRaise(player) && !p.isRaiseCapped() && maxRaiseBet > p.TurnBet
+	if canRaise {
+		legal.Types = append(legal.Types, Raise)
+		legal.MinRaiseTo = p.MinRaiseBet()
+		if legal.MinRaiseTo > maxRaiseBet {
+			legal.MinRaiseTo = maxRaiseBet
+		}
+		legal.MaxRaiseTo = maxRaiseBet
+	}
+	// オールインはコールの額に届かない場合か、ベットのルールの上限以内でレイズできる場合に選択できる
+	if allInBet <= p.TurnBet || (canRaise && allInBet <= maxRaiseBet) {
+		legal.Types = append(legal.Types, AllIn)
+	}
+	return legal
+}
@@ -0,0 +1,137 @@
+package poker
+
+import (
+	"testing"
+)
+
+func TestLegalActions(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func(t *testing.T) *Poker
+		types      []ActionType
+		callAmount int
+		minRaiseTo int
+		maxRaiseTo int
+	}{
+		{
+			name: "NLのオープン",
+			setup: func(t *testing.T) *Poker {
+				p := newTestPoker(t, 100, 50, 3000, 3000, 3000)
+				p.StartHand()
+				return p
+			},
+			types:      []ActionType{Fold, Call, Raise, AllIn},
+			callAmount: 100, minRaiseTo: 200, maxRaiseTo: 3000,
+		},
+		{
+			name: "NLでレイズに直面する",
+			setup: func(t *testing.T) *Poker {
+				p := newTestPoker(t, 100, 50, 3000, 3000, 3000)
+				p.StartHand()
+				act(t, p, Action{Type: Raise, Bet: 300})
+				return p
+			},
+			types:      []ActionType{Fold, Call, Raise, AllIn},
+			callAmount: 250, minRaiseTo: 500, maxRaiseTo: 3000,
+		},
+		{
+			name: "PLの上限はコールした後のポット",
+			setup: func(t *testing.T) *Poker {
+				p := newTestPoker(t, 100, 50, 3000, 3000, 3000)
+				p.Structure = PotLimit
+				p.StartHand()
+				act(t, p, Action{Type: Call})
+				return p
+			},
+			types:      []ActionType{Fold, Call, Raise},
+			callAmount: 50, minRaiseTo: 200, maxRaiseTo: 400,
+		},
+		{
+			name: "FLは1段階だけレイズできる",
+			setup: func(t *testing.T) *Poker {
+				p := newTestPoker(t, 100, 50, 3000, 3000, 3000)
+				p.Structure = FixedLimit
+				p.StartHand()
+				return p
+			},
+			types:      []ActionType{Fold, Call, Raise},
+			callAmount: 100, minRaiseTo: 200, maxRaiseTo: 200,
+		},
+		{
+			name: "FLでレイズ回数が上限に達する",
+			setup: func(t *testing.T) *Poker {
+				p := newTestPoker(t, 100, 50, 3000, 3000, 3000)
+				p.Structure = FixedLimit
+				p.StartHand()
+				for _, bet := range []int{200, 300, 400} {
+					act(t, p, Action{Type: Raise, Bet: bet})
+				}
+				return p
+			},
+			types:      []ActionType{Fold, Call},
+			callAmount: 200,
+		},
+		{
+			name: "コールに足りないスタックはオールインでコールする",
+			setup: func(t *testing.T) *Poker {
+				p := newTestPoker(t, 100, 50, 3000, 150, 3000)
+				p.StartHand()
+				act(t, p, Action{Type: Raise, Bet: 500})
+				return p
+			},
+			types:      []ActionType{Fold, Call, AllIn},
+			callAmount: 100,
+		},
+		{
+			name: "最低レイズ額に満たないスタックはオールインの額だけレイズできる",
+			setup: func(t *testing.T) *Poker {
+				p := newTestPoker(t, 100, 50, 3000, 400, 3000)
+				p.StartHand()
+				act(t, p, Action{Type: Raise, Bet: 300})
+				return p
+			},
+			types:      []ActionType{Fold, Call, Raise, AllIn},
+			callAmount: 250, minRaiseTo: 400, maxRaiseTo: 400,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.setup(t)
+			legal := p.LegalActions(p.getCurrentPlayer())
+			if len(legal.Types) != len(tt.types) {
+				t.Fatalf("types = %v, want %v", legal.Types, tt.types)
+			}
+			for i := range tt.types {
+				if legal.Types[i] != tt.types[i] {
+					t.Fatalf("types = %v, want %v", legal.Types, tt.types)
+				}
+			}
+			if legal.CallAmount != tt.callAmount || legal.MinRaiseTo != tt.minRaiseTo || legal.MaxRaiseTo != tt.maxRaiseTo {
+				t.Errorf("call = %d, raise to %d-%d, want %d, %d-%d", legal.CallAmount, legal.MinRaiseTo, legal.MaxRaiseTo, tt.callAmount, tt.minRaiseTo, tt.maxRaiseTo)
+			}
+
+			// 選択できるアクションは、レイズの下限と上限も含めて全てActionが受け付ける
+			var actions []Action
+			for _, actionType := range legal.Types {
+				if actionType == Raise {
+					actions = append(actions, Action{Type: Raise, Bet: legal.MinRaiseTo}, Action{Type: Raise, Bet: legal.MaxRaiseTo})
+				} else {
+					actions = append(actions, Action{Type: actionType})
+				}
+			}
+			for _, a := range actions {
+				p := tt.setup(t)
+				if err := p.Action(a); err != nil {
+					t.Errorf("Action(%s %d): %v", a.Type, a.Bet, err)
+				}
+			}
+			// 所持金が足りていても、上限を超えるレイズは受け付けない
+			player := p.getCurrentPlayer()
+			if over := legal.MaxRaiseTo + 100; legal.Can(Raise) && over <= player.CurrentBet+player.Money {
+				if err := tt.setup(t).Action(Action{Type: Raise, Bet: over}); err == nil {
+					t.Errorf("Action accepted a raise to %d above MaxRaiseTo %d", over, legal.MaxRaiseTo)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package poker
+
+import (
+	"fmt"
+	"io"
+)
+
+// Listener はゲームの進行をイベントとして受け取る
+// TUIやログ出力など、ゲームの表示はListenerとして実装する
+type Listener interface {
+	OnEvent(e Event)
+}
+
+// ListenerFunc は関数をListenerとして扱う
+type ListenerFunc func(e Event)
+
+func (f ListenerFunc) OnEvent(e Event) {
+	f(e)
+}
+
+// AddListener はイベントを受け取るリスナーを登録する
+func (p *Poker) AddListener(l Listener) {
+	p.Listeners = append(p.Listeners, l)
+}
+
+// emit は登録された全てのリスナーにイベントを送る
+// イベントはリスナーに送る前にハンドログへ記録する
+func (p *Poker) emit(e Event) {
+	p.recordEvent(e)
+	for _, l := range p.Listeners {
+		l.OnEvent(e)
+	}
+}
+
+// NewTextListener はイベントの文言を1行ずつ書き出すリスナーを作成する
+func NewTextListener(w io.Writer) Listener {
+	return ListenerFunc(func(e Event) {
+		fmt.Fprintln(w, e)
+	})
+}
@@ -0,0 +1,285 @@
+package poker
+
+import "go_poker/i18n"
+
+// メッセージカタログのキー。翻訳はmessages_ja.goとmessages_en.goにある
+const (
+	// ハンドの進行
+	msgInvalidBlinds     i18n.Message = "poker.invalid_blinds"
+	msgHandNotFinished   i18n.Message = "poker.hand_not_finished"
+	msgGameOver          i18n.Message = "poker.game_over"
+	msgNotEnoughPlayers  i18n.Message = "poker.not_enough_players"
+	msgHandFinished      i18n.Message = "poker.hand_finished"
+	msgNothingToCall     i18n.Message = "poker.nothing_to_call"
+	msgCannotCheck       i18n.Message = "poker.cannot_check"
+	msgNotEnoughMoney    i18n.Message = "poker.not_enough_money"
+	msgCannotRabbitHunt  i18n.Message = "poker.cannot_rabbit_hunt"
+	msgCannotShowCards   i18n.Message = "poker.cannot_show_cards"
+	msgChoosingRuns      i18n.Message = "poker.choosing_runs"
+	msgNotChoosingRuns   i18n.Message = "poker.not_choosing_runs"
+	msgInvalidRunItTimes i18n.Message = "poker.invalid_run_it_times"
+
+	// レイズ
+	msgRaiseCapReached  i18n.Message = "poker.raise_cap_reached"
+	msgRaiseNotReopened i18n.Message = "poker.raise_not_reopened"
+	msgRaiseNotAboveBet i18n.Message = "poker.raise_not_above_bet"
+	msgRaiseOverStack   i18n.Message = "poker.raise_over_stack"
+	msgRaiseOverLimit   i18n.Message = "poker.raise_over_limit"
+	msgRaiseTooSmall    i18n.Message = "poker.raise_too_small"
+
+	// キャッシュゲーム
+	msgInvalidBuyInRange i18n.Message = "poker.invalid_buy_in_range"
+	msgBuyInOutOfRange   i18n.Message = "poker.buy_in_out_of_range"
+	msgNotCashGame       i18n.Message = "poker.not_cash_game"
+	msgNotBetweenHands   i18n.Message = "poker.not_between_hands"
+	msgLeftPlayerRebuy   i18n.Message = "poker.left_player_rebuy"
+	msgHasChips          i18n.Message = "poker.has_chips"
+	msgLeftPlayerTopUp   i18n.Message = "poker.left_player_top_up"
+	msgTopUpOverMax      i18n.Message = "poker.top_up_over_max"
+	msgAlreadyMaxBuyIn   i18n.Message = "poker.already_max_buy_in"
+	msgNoEmptySeat       i18n.Message = "poker.no_empty_seat"
+
+	// 座席
+	msgInvalidSeat       i18n.Message = "poker.invalid_seat"
+	msgInvalidSeatMoney  i18n.Message = "poker.invalid_seat_money"
+	msgInvalidController i18n.Message = "poker.invalid_controller"
+	msgPlayerCount       i18n.Message = "poker.player_count"
+	msgNoPlayerName      i18n.Message = "poker.no_player_name"
+	msgDuplicateName     i18n.Message = "poker.duplicate_name"
+	msgSeatMoneyTooSmall i18n.Message = "poker.seat_money_too_small"
+
+	// トーナメント
+	msgInvalidTournament i18n.Message = "poker.invalid_tournament"
+	msgInvalidPayout     i18n.Message = "poker.invalid_payout"
+	msgPayoutTotal       i18n.Message = "poker.payout_total"
+	msgInvalidPayouts    i18n.Message = "poker.invalid_payouts"
+	msgFinalStandings    i18n.Message = "poker.final_standings"
+	msgInterimStandings  i18n.Message = "poker.interim_standings"
+
+	// ブラインドストラクチャー
+	msgLoadBlindSchedule  i18n.Message = "poker.load_blind_schedule"
+	msgNoBlindLevels      i18n.Message = "poker.no_blind_levels"
+	msgInvalidLevelBlinds i18n.Message = "poker.invalid_level_blinds"
+	msgNegativeLevel      i18n.Message = "poker.negative_level"
+	msgLevelLength        i18n.Message = "poker.level_length"
+
+	// 保存と再開
+	msgLoadSavedGame         i18n.Message = "poker.load_saved_game"
+	msgSavedGameVersion      i18n.Message = "poker.saved_game_version"
+	msgSavedEliminatedSeat   i18n.Message = "poker.saved_eliminated_seat"
+	msgLoadSavedEvent        i18n.Message = "poker.load_saved_event"
+	msgSavedPlayerCount      i18n.Message = "poker.saved_player_count"
+	msgNoSeat                i18n.Message = "poker.no_seat"
+	msgNoBlindLevel          i18n.Message = "poker.no_blind_level"
+	msgTooManyCards          i18n.Message = "poker.too_many_cards"
+	msgUnsupportedSavedEvent i18n.Message = "poker.unsupported_saved_event"
+
+	// ハンドログと再生
+	msgNoEvents       i18n.Message = "poker.no_events"
+	msgNotHandStarted i18n.Message = "poker.not_hand_started"
+	msgBoardNotDealt  i18n.Message = "poker.board_not_dealt"
+	msgSeatNotInHand  i18n.Message = "poker.seat_not_in_hand"
+	msgNoHands        i18n.Message = "poker.no_hands"
+	msgHandOutOfRange i18n.Message = "poker.hand_out_of_range"
+
+	// イベント
+	msgHandStarted      i18n.Message = "poker.event.hand_started"
+	msgStraddlePosted   i18n.Message = "poker.event.straddle_posted"
+	msgBlindPosted      i18n.Message = "poker.event.blind_posted"
+	msgHoleCardsDealt   i18n.Message = "poker.event.hole_cards_dealt"
+	msgBlindLevelRaised i18n.Message = "poker.event.blind_level_raised"
+	msgTurnStarted      i18n.Message = "poker.event.turn_started"
+	msgActionTaken      i18n.Message = "poker.event.action_taken"
+	msgFlopDealt        i18n.Message = "poker.event.flop_dealt"
+	msgBoardDealt       i18n.Message = "poker.event.board_dealt"
+	msgShowdownRevealed i18n.Message = "poker.event.showdown_revealed"
+	msgHandMucked       i18n.Message = "poker.event.hand_mucked"
+	msgCardsShown       i18n.Message = "poker.event.cards_shown"
+	msgBetReturned      i18n.Message = "poker.event.bet_returned"
+	msgPotWon           i18n.Message = "poker.event.pot_won"
+	msgPotSplit         i18n.Message = "poker.event.pot_split"
+	msgPotShare         i18n.Message = "poker.event.pot_share"
+	msgMainPot          i18n.Message = "poker.event.main_pot"
+	msgSidePot          i18n.Message = "poker.event.side_pot"
+	msgPlayerEliminated i18n.Message = "poker.event.player_eliminated"
+	msgHandEnded        i18n.Message = "poker.event.hand_ended"
+	msgWonAllChips      i18n.Message = "poker.event.won_all_chips"
+	msgGameEnded        i18n.Message = "poker.event.game_ended"
+	msgRebought         i18n.Message = "poker.event.rebought"
+	msgToppedUp         i18n.Message = "poker.event.topped_up"
+	msgSatOut           i18n.Message = "poker.event.sat_out"
+	msgSatIn            i18n.Message = "poker.event.sat_in"
+	msgLeft             i18n.Message = "poker.event.left"
+	msgJoined           i18n.Message = "poker.event.joined"
+	msgBoardRunDealt    i18n.Message = "poker.event.board_run_dealt"
+	msgRunItOffered     i18n.Message = "poker.event.run_it_offered"
+	msgRunItAgreed      i18n.Message = "poker.event.run_it_agreed"
+	msgOnBoard          i18n.Message = "poker.event.on_board"
+	msgRabbitHunted     i18n.Message = "poker.event.rabbit_hunted"
+
+	// 画面
+	msgNextHandPrompt   i18n.Message = "poker.viewer.next_hand_prompt"
+	msgTooFewPlayers    i18n.Message = "poker.viewer.too_few_players"
+	msgInformationTitle i18n.Message = "poker.viewer.information_title"
+	msgRaiseToLabel     i18n.Message = "poker.viewer.raise_to_label"
+	msgLevelText        i18n.Message = "poker.viewer.level_text"
+	msgHandsLeft        i18n.Message = "poker.viewer.hands_left"
+	msgYourTurn         i18n.Message = "poker.viewer.your_turn"
+	msgWillStraddle     i18n.Message = "poker.viewer.will_straddle"
+	msgStopStraddle     i18n.Message = "poker.viewer.stop_straddle"
+	msgWillMuck         i18n.Message = "poker.viewer.will_muck"
+	msgWillShowHands    i18n.Message = "poker.viewer.will_show_hands"
+	msgActionNotAllowed i18n.Message = "poker.viewer.action_not_allowed"
+	msgRunItTimes       i18n.Message = "poker.viewer.run_it_times"
+	msgRunItOnce        i18n.Message = "poker.viewer.run_it_once"
+	msgRunItLabel       i18n.Message = "poker.viewer.run_it_label"
+
+	// リプレイ
+	msgReplayTitle         i18n.Message = "poker.replay.title"
+	msgReplayPaused        i18n.Message = "poker.replay.paused"
+	msgReplayPlaying       i18n.Message = "poker.replay.playing"
+	msgReplaySpeed         i18n.Message = "poker.replay.speed"
+	msgReplayNext          i18n.Message = "poker.replay.next"
+	msgReplayNextHelp      i18n.Message = "poker.replay.next_help"
+	msgReplayBack          i18n.Message = "poker.replay.back"
+	msgReplayBackHelp      i18n.Message = "poker.replay.back_help"
+	msgReplayPlayPause     i18n.Message = "poker.replay.play_pause"
+	msgReplayPlayPauseHelp i18n.Message = "poker.replay.play_pause_help"
+	msgReplayFaster        i18n.Message = "poker.replay.faster"
+	msgReplayFasterHelp    i18n.Message = "poker.replay.faster_help"
+	msgReplaySlower        i18n.Message = "poker.replay.slower"
+	msgReplaySlowerHelp    i18n.Message = "poker.replay.slower_help"
+	msgReplayNextHand      i18n.Message = "poker.replay.next_hand"
+	msgReplayNextHandHelp  i18n.Message = "poker.replay.next_hand_help"
+	msgReplayPrevHand      i18n.Message = "poker.replay.prev_hand"
+	msgReplayPrevHandHelp  i18n.Message = "poker.replay.prev_hand_help"
+	msgReplayQuit          i18n.Message = "poker.replay.quit"
+	msgReplayQuitHelp      i18n.Message = "poker.replay.quit_help"
+	msgReplayHandTitle     i18n.Message = "poker.replay.hand_title"
+	msgReplayStep          i18n.Message = "poker.replay.step"
+	msgReplayMuck          i18n.Message = "poker.replay.muck"
+
+	// ハンド履歴の読み込み
+	msgParseErrorLine          i18n.Message = "poker.parse.error_line"
+	msgSeatAfterBlinds         i18n.Message = "poker.parse.seat_after_blinds"
+	msgUnknownLine             i18n.Message = "poker.parse.unknown_line"
+	msgNotHandHeader           i18n.Message = "poker.parse.not_hand_header"
+	msgNotHoldem               i18n.Message = "poker.parse.not_holdem"
+	msgInvalidHandNumber       i18n.Message = "poker.parse.invalid_hand_number"
+	msgNoBlinds                i18n.Message = "poker.parse.no_blinds"
+	msgNoRaiseTo               i18n.Message = "poker.parse.no_raise_to"
+	msgUnknownPlayer           i18n.Message = "poker.parse.unknown_player"
+	msgInvalidAmount           i18n.Message = "poker.parse.invalid_amount"
+	msgNthHand                 i18n.Message = "poker.parse.nth_hand"
+	msgUnsupportedGame         i18n.Message = "poker.parse.unsupported_game"
+	msgUnsupportedBetLimit     i18n.Message = "poker.parse.unsupported_bet_limit"
+	msgTwoPlayersNeeded        i18n.Message = "poker.parse.two_players_needed"
+	msgInRound                 i18n.Message = "poker.parse.in_round"
+	msgInAction                i18n.Message = "poker.parse.in_action"
+	msgPotWinnerNotSeated      i18n.Message = "poker.parse.pot_winner_not_seated"
+	msgUnsupportedVariant      i18n.Message = "poker.parse.unsupported_variant"
+	msgPlayersMismatch         i18n.Message = "poker.parse.players_mismatch"
+	msgSeatsMismatch           i18n.Message = "poker.parse.seats_mismatch"
+	msgActionNotString         i18n.Message = "poker.parse.action_not_string"
+	msgActionTooShort          i18n.Message = "poker.parse.action_too_short"
+	msgInvalidPlayer           i18n.Message = "poker.parse.invalid_player"
+	msgInvalidBoardCount       i18n.Message = "poker.parse.invalid_board_count"
+	msgUnsupportedDealing      i18n.Message = "poker.parse.unsupported_dealing"
+	msgUnsupportedAction       i18n.Message = "poker.parse.unsupported_action"
+	msgInvalidCards            i18n.Message = "poker.parse.invalid_cards"
+	msgNotKeyValue             i18n.Message = "poker.parse.not_key_value"
+	msgTrailingCharacters      i18n.Message = "poker.parse.trailing_characters"
+	msgDuplicateKey            i18n.Message = "poker.parse.duplicate_key"
+	msgNoValue                 i18n.Message = "poker.parse.no_value"
+	msgUnterminatedString      i18n.Message = "poker.parse.unterminated_string"
+	msgInvalidString           i18n.Message = "poker.parse.invalid_string"
+	msgUnterminatedArray       i18n.Message = "poker.parse.unterminated_array"
+	msgNoArraySeparator        i18n.Message = "poker.parse.no_array_separator"
+	msgMissingKey              i18n.Message = "poker.parse.missing_key"
+	msgNotString               i18n.Message = "poker.parse.not_string"
+	msgNotNumber               i18n.Message = "poker.parse.not_number"
+	msgNotArray                i18n.Message = "poker.parse.not_array"
+	msgCountMismatch           i18n.Message = "poker.parse.count_mismatch"
+	msgPostOverStack           i18n.Message = "poker.parse.post_over_stack"
+	msgRaiseNotAbove           i18n.Message = "poker.parse.raise_not_above"
+	msgBetOverStack            i18n.Message = "poker.parse.bet_over_stack"
+	msgNoBoardCards            i18n.Message = "poker.parse.no_board_cards"
+	msgBoardOverFive           i18n.Message = "poker.parse.board_over_five"
+	msgShowdownCardsUnknown    i18n.Message = "poker.parse.showdown_cards_unknown"
+	msgShowdownBoardIncomplete i18n.Message = "poker.parse.showdown_board_incomplete"
+)
+
+// エンジンが返すエラー。errors.Isで種類を判定できる
+// 額などを埋め込んだエラーもWithで作るため、同じ種類として判定できる
+var (
+	// ハンドの進行
+	ErrInvalidBlinds     = i18n.NewError(msgInvalidBlinds)
+	ErrHandNotFinished   = i18n.NewError(msgHandNotFinished)
+	ErrGameOver          = i18n.NewError(msgGameOver)
+	ErrNotEnoughPlayers  = i18n.NewError(msgNotEnoughPlayers)
+	ErrHandFinished      = i18n.NewError(msgHandFinished)
+	ErrNothingToCall     = i18n.NewError(msgNothingToCall)
+	ErrCannotCheck       = i18n.NewError(msgCannotCheck)
+	ErrNotEnoughMoney    = i18n.NewError(msgNotEnoughMoney)
+	ErrCannotRabbitHunt  = i18n.NewError(msgCannotRabbitHunt)
+	ErrCannotShowCards   = i18n.NewError(msgCannotShowCards)
+	ErrChoosingRuns      = i18n.NewError(msgChoosingRuns)
+	ErrNotChoosingRuns   = i18n.NewError(msgNotChoosingRuns)
+	ErrInvalidRunItTimes = i18n.NewError(msgInvalidRunItTimes)
+
+	// レイズ
+	ErrRaiseCapReached  = i18n.NewError(msgRaiseCapReached)
+	ErrRaiseNotReopened = i18n.NewError(msgRaiseNotReopened)
+	ErrRaiseNotAboveBet = i18n.NewError(msgRaiseNotAboveBet)
+	ErrRaiseOverStack   = i18n.NewError(msgRaiseOverStack)
+	ErrRaiseOverLimit   = i18n.NewError(msgRaiseOverLimit)
+	ErrRaiseTooSmall    = i18n.NewError(msgRaiseTooSmall)
+
+	// キャッシュゲーム
+	ErrInvalidBuyInRange = i18n.NewError(msgInvalidBuyInRange)
+	ErrBuyInOutOfRange   = i18n.NewError(msgBuyInOutOfRange)
+	ErrNotCashGame       = i18n.NewError(msgNotCashGame)
+	ErrNotBetweenHands   = i18n.NewError(msgNotBetweenHands)
+	ErrLeftPlayerRebuy   = i18n.NewError(msgLeftPlayerRebuy)
+	ErrHasChips          = i18n.NewError(msgHasChips)
+	ErrLeftPlayerTopUp   = i18n.NewError(msgLeftPlayerTopUp)
+	ErrTopUpOverMax      = i18n.NewError(msgTopUpOverMax)
+	ErrAlreadyMaxBuyIn   = i18n.NewError(msgAlreadyMaxBuyIn)
+	ErrNoEmptySeat       = i18n.NewError(msgNoEmptySeat)
+
+	// 座席
+	ErrInvalidSeat       = i18n.NewError(msgInvalidSeat)
+	ErrInvalidSeatMoney  = i18n.NewError(msgInvalidSeatMoney)
+	ErrInvalidController = i18n.NewError(msgInvalidController)
+	ErrPlayerCount       = i18n.NewError(msgPlayerCount)
+	ErrNoPlayerName      = i18n.NewError(msgNoPlayerName)
+	ErrDuplicateName     = i18n.NewError(msgDuplicateName)
+	ErrSeatMoneyTooSmall = i18n.NewError(msgSeatMoneyTooSmall)
+
+	// トーナメント
+	ErrInvalidTournament = i18n.NewError(msgInvalidTournament)
+	ErrInvalidPayout     = i18n.NewError(msgInvalidPayout)
+	ErrPayoutTotal       = i18n.NewError(msgPayoutTotal)
+	ErrInvalidPayouts    = i18n.NewError(msgInvalidPayouts)
+
+	// ブラインドストラクチャー
+	ErrLoadBlindSchedule  = i18n.NewError(msgLoadBlindSchedule)
+	ErrNoBlindLevels      = i18n.NewError(msgNoBlindLevels)
+	ErrInvalidLevelBlinds = i18n.NewError(msgInvalidLevelBlinds)
+	ErrNegativeLevel      = i18n.NewError(msgNegativeLevel)
+	ErrLevelLength        = i18n.NewError(msgLevelLength)
+
+	// 保存と再開
+	ErrLoadSavedGame    = i18n.NewError(msgLoadSavedGame)
+	ErrSavedGameVersion = i18n.NewError(msgSavedGameVersion)
+
+	// ハンドログと再生
+	ErrNoEvents       = i18n.NewError(msgNoEvents)
+	ErrNotHandStarted = i18n.NewError(msgNotHandStarted)
+	ErrNoHands        = i18n.NewError(msgNoHands)
+)
+
+func init() {
+	i18n.Register(i18n.Japanese, japaneseMessages)
+	i18n.Register(i18n.English, englishMessages)
+}
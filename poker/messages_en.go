@@ -0,0 +1,210 @@
+package poker
+
+import "go_poker/i18n"
+
+// englishMessages は英語のメッセージ
+var englishMessages = map[i18n.Message]string{
+	// ハンドの進行
+	msgInvalidBlinds:     "The big blind must be larger than the small blind",
+	msgHandNotFinished:   "The hand has not finished yet.",
+	msgGameOver:          "The game is over.",
+	msgNotEnoughPlayers:  "At least two players must be ready for the next hand.",
+	msgHandFinished:      "This hand has already finished.",
+	msgNothingToCall:     "There is nothing to call. Choose Raise or Check.",
+	msgCannotCheck:       "You are facing a bet. Choose Call or Raise.",
+	msgNotEnoughMoney:    "Not enough chips for this bet",
+	msgCannotRabbitHunt:  "You can only rabbit hunt after a hand ends with a fold before the whole board is dealt.",
+	msgCannotShowCards:   "Only the player who won after everyone folded can show their cards, once the hand is over.",
+	msgChoosingRuns:      "No action can be taken while the number of runs is being chosen.",
+	msgNotChoosingRuns:   "Only players left in the hand who have not chosen yet can choose how many times to run the board, once everyone is all-in.",
+	msgInvalidRunItTimes: "Choose to run the board between 1 and %d times.",
+
+	// レイズ
+	msgRaiseCapReached:  "The betting is capped at %d bets. Choose Call or Fold.",
+	msgRaiseNotReopened: "The betting has not been reopened for you. Choose Call or Fold.",
+	msgRaiseNotAboveBet: "A raise must be larger than the current bet.",
+	msgRaiseOverStack:   "You cannot raise more than your stack. Raise to %d or less.",
+	msgRaiseOverLimit:   "In %s you can raise to %d at most.",
+	msgRaiseTooSmall:    "The raise is too small. Raise to %d or more.",
+
+	// キャッシュゲーム
+	msgInvalidBuyInRange: "The minimum buy-in must be positive and the maximum must not be below it",
+	msgBuyInOutOfRange:   "The buy-in must be between ＄%d and ＄%d",
+	msgNotCashGame:       "This is not a cash game",
+	msgNotBetweenHands:   "This can only be done between hands",
+	msgLeftPlayerRebuy:   "A player who has left the table cannot rebuy",
+	msgHasChips:          "Players with chips left should top up instead",
+	msgLeftPlayerTopUp:   "A player who has left the table cannot top up",
+	msgTopUpOverMax:      "The stack after topping up must be ＄%d or less",
+	msgAlreadyMaxBuyIn:   "The stack is already at the maximum buy-in",
+	msgNoEmptySeat:       "There is no empty seat",
+
+	// 座席
+	msgInvalidSeat:       "Invalid seat: %s",
+	msgInvalidSeatMoney:  "Invalid money: %s",
+	msgInvalidController: "The controller must be human or bot: %s",
+	msgPlayerCount:       "The number of players must be between %d and %d",
+	msgNoPlayerName:      "Every player needs a name",
+	msgDuplicateName:     "Duplicate player name: %s",
+	msgSeatMoneyTooSmall: "Every player's money must be larger than the big blind",
+
+	// トーナメント
+	msgInvalidTournament: "The buy-in and starting stack must be positive",
+	msgInvalidPayout:     "Every payout percentage must be positive",
+	msgPayoutTotal:       "The payout percentages add up to %d%% instead of 100%%",
+	msgInvalidPayouts:    "Invalid payouts: %s",
+	msgFinalStandings:    "Final standings (buy-in ＄%d, prize pool ＄%d)",
+	msgInterimStandings:  "Interim standings (prizes are paid when the game ends)",
+
+	// ブラインドストラクチャー
+	msgLoadBlindSchedule:  "Cannot read the blind schedule",
+	msgNoBlindLevels:      "The blind schedule has no levels",
+	msgInvalidLevelBlinds: "Level %d: the big blind must be larger than the small blind",
+	msgNegativeLevel:      "Level %d: values cannot be negative",
+	msgLevelLength:        "Level %d: give a length in minutes or hands",
+
+	// 保存と再開
+	msgLoadSavedGame:         "Cannot read the saved game",
+	msgSavedGameVersion:      "Saved game format version %d is not supported",
+	msgSavedEliminatedSeat:   "There is no seat %d for an eliminated player",
+	msgLoadSavedEvent:        "Cannot read the saved %s event",
+	msgSavedPlayerCount:      "The number of players must be between %d and %d",
+	msgNoSeat:                "There is no seat %d",
+	msgNoBlindLevel:          "There is no blind level %d",
+	msgTooManyCards:          "The deck has too many cards",
+	msgUnsupportedSavedEvent: "Saved %s events are not supported",
+
+	// ハンドログと再生
+	msgNoEvents:       "There are no events",
+	msgNotHandStarted: "The first event is not HandStarted",
+	msgBoardNotDealt:  "The cards of board %d before the %s have not been dealt",
+	msgSeatNotInHand:  "The player in seat %d is not in the hand",
+	msgNoHands:        "There are no hands to replay",
+	msgHandOutOfRange: "Hands are numbered 1 to %d",
+
+	// イベント
+	msgHandStarted:      "Starting hand #%d.",
+	msgStraddlePosted:   "%s straddled for %d.",
+	msgBlindPosted:      "%s posted the %s of %d.",
+	msgHoleCardsDealt:   "Dealt cards to %s.",
+	msgBlindLevelRaised: "The blinds went up to level %d (%s).",
+	msgTurnStarted:      "%s is next to act.",
+	msgActionTaken:      "%s chose %s.",
+	msgFlopDealt:        "Dealing the flop.",
+	msgBoardDealt:       "Adding a card to the board.",
+	msgShowdownRevealed: "%s shows %s",
+	msgHandMucked:       "%s mucks the hand",
+	msgCardsShown:       "%s shows [%s]",
+	msgBetReturned:      "Returned the uncalled %[2]d to %[1]s",
+	msgPotWon:           "%s won the %s",
+	msgPotSplit:         "%s split the %s",
+	msgPotShare:         "Won: %d",
+	msgMainPot:          "main pot",
+	msgSidePot:          "side pot %d",
+	msgPlayerEliminated: "%s was eliminated in place %d",
+	msgHandEnded:        "Hand #%d is over.",
+	msgWonAllChips:      "%s won all the chips",
+	msgGameEnded:        "The game ended after %d hands",
+	msgRebought:         "%s rebought for ＄%d",
+	msgToppedUp:         "%s topped up ＄%d",
+	msgSatOut:           "%s sat out",
+	msgSatIn:            "%s sat back in",
+	msgLeft:             "%s left the table with ＄%d (net: %s)",
+	msgJoined:           "%s took seat %d",
+	msgBoardRunDealt:    "Dealt [%[2]s] to board %[1]d.",
+	msgRunItOffered:     "Everyone is all-in. Choose to run the rest of the board 1 to %d times.",
+	msgRunItAgreed:      "Everyone is all-in. The rest of the board will be run %d times.",
+	msgOnBoard:          "Board %d: %s",
+	msgRabbitHunted:     "Rabbit hunt: the next cards would have been [%s].",
+
+	// 画面
+	msgNextHandPrompt:   "Choose 「Next Hand」 to deal the next hand.",
+	msgTooFewPlayers:    "There are not enough players.",
+	msgInformationTitle: "Information",
+	msgRaiseToLabel:     "Raise to: ",
+	msgLevelText:        "Level %d: %s",
+	msgHandsLeft:        "%d hands left",
+	msgYourTurn:         "It is %s's turn. Choose an action.",
+	msgWillStraddle:     "%s will straddle from the next hand.",
+	msgStopStraddle:     "%s stops straddling.",
+	msgWillMuck:         "%s will muck losing hands at showdown.",
+	msgWillShowHands:    "%s will show every hand at showdown.",
+	msgActionNotAllowed: "%s is not allowed now.",
+	msgRunItTimes:       "%s wants to run the rest of the board %d times.",
+	msgRunItOnce:        "%s wants to run the rest of the board once.",
+	msgRunItLabel:       "Run it: ",
+
+	// リプレイ
+	msgReplayTitle:         "Replay",
+	msgReplayPaused:        "Paused",
+	msgReplayPlaying:       "Playing",
+	msgReplaySpeed:         "%.1fs / step",
+	msgReplayNext:          "Next",
+	msgReplayNextHelp:      "Step forward (→)",
+	msgReplayBack:          "Back",
+	msgReplayBackHelp:      "Step backward (←)",
+	msgReplayPlayPause:     "Play / Pause",
+	msgReplayPlayPauseHelp: "Advance automatically (Space)",
+	msgReplayFaster:        "Faster",
+	msgReplayFasterHelp:    "Shorten the auto-advance interval",
+	msgReplaySlower:        "Slower",
+	msgReplaySlowerHelp:    "Lengthen the auto-advance interval",
+	msgReplayNextHand:      "Next Hand",
+	msgReplayNextHandHelp:  "Jump to the start of the next hand",
+	msgReplayPrevHand:      "Previous Hand",
+	msgReplayPrevHandHelp:  "Jump to the start of the previous hand",
+	msgReplayQuit:          "Quit",
+	msgReplayQuitHelp:      "Press to exit the replay",
+	msgReplayHandTitle:     "Hand %d / %d",
+	msgReplayStep:          "#%d  %s  Step %d / %d",
+	msgReplayMuck:          " (Muck)",
+
+	// ハンド履歴の読み込み
+	msgParseErrorLine:          "line %d: %s: %s",
+	msgSeatAfterBlinds:         "Seat lines must come before the blinds",
+	msgUnknownLine:             "Unrecognized line",
+	msgNotHandHeader:           "Not the first line of a hand",
+	msgNotHoldem:               "Only Hold'em is supported",
+	msgInvalidHandNumber:       "Invalid hand number",
+	msgNoBlinds:                "The blinds are missing",
+	msgNoRaiseTo:               "The raise-to amount is missing",
+	msgUnknownPlayer:           "Player is not seated: %v",
+	msgInvalidAmount:           "Invalid amount: %s",
+	msgNthHand:                 "hand %d (#%s)",
+	msgUnsupportedGame:         "Unsupported game: %s",
+	msgUnsupportedBetLimit:     "Unsupported bet limit: %s",
+	msgTwoPlayersNeeded:        "At least two players are needed",
+	msgInRound:                 "%s round",
+	msgInAction:                "action %d (%s)",
+	msgPotWinnerNotSeated:      "The winner of pot %d is not seated: %d",
+	msgUnsupportedVariant:      "Unsupported variant: %s",
+	msgPlayersMismatch:         "The number of players does not match starting_stacks",
+	msgSeatsMismatch:           "The number of seats does not match starting_stacks",
+	msgActionNotString:         "The action is not a string",
+	msgActionTooShort:          "The action is too short",
+	msgInvalidPlayer:           "Invalid player: %s",
+	msgInvalidBoardCount:       "Invalid number of board cards",
+	msgUnsupportedDealing:      "Unsupported dealing action",
+	msgUnsupportedAction:       "Unsupported action: %s",
+	msgInvalidCards:            "Invalid cards: %s",
+	msgNotKeyValue:             "Not a key and value pair",
+	msgTrailingCharacters:      "Unexpected characters after the value",
+	msgDuplicateKey:            "Duplicate key",
+	msgNoValue:                 "The value is missing",
+	msgUnterminatedString:      "Unterminated string",
+	msgInvalidString:           "Invalid string: %s",
+	msgUnterminatedArray:       "Unterminated array",
+	msgNoArraySeparator:        "Missing separator between array elements",
+	msgMissingKey:              "Required key is missing",
+	msgNotString:               "Not a string",
+	msgNotNumber:               "Not a number",
+	msgNotArray:                "Not an array",
+	msgCountMismatch:           "The number of elements does not match starting_stacks",
+	msgPostOverStack:           "%s posts more than their stack",
+	msgRaiseNotAbove:           "%s raises to %d, which is not above their current bet",
+	msgBetOverStack:            "%s bets more than their stack",
+	msgNoBoardCards:            "There are no board cards",
+	msgBoardOverFive:           "The board has more than five cards",
+	msgShowdownCardsUnknown:    "Cannot award the pot because %s's hole cards at showdown are unknown",
+	msgShowdownBoardIncomplete: "Cannot award the pot because the board at showdown has fewer than five cards",
+}
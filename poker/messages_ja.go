@@ -0,0 +1,210 @@
+package poker
+
+import "go_poker/i18n"
+
+// japaneseMessages は日本語のメッセージ
+var japaneseMessages = map[i18n.Message]string{
+	// ハンドの進行
+	msgInvalidBlinds:     "BBはSBより大きい値を指定してください",
+	msgHandNotFinished:   "ハンドが終了していません。",
+	msgGameOver:          "ゲームは終了しました。",
+	msgNotEnoughPlayers:  "次のハンドに参加できるプレイヤーが2人以上必要です。",
+	msgHandFinished:      "このハンドは終了しています。",
+	msgNothingToCall:     "ベット額が既に足りています。RaiseかCheckを選択してください。",
+	msgCannotCheck:       "ベット額が足りていません。CallかRaiseを選択してください。",
+	msgNotEnoughMoney:    "ベット額が足りません",
+	msgCannotRabbitHunt:  "ラビットハントできるのは、フォールドでハンドが終わり、まだ配られていないボードのカードがあるときだけです。",
+	msgCannotShowCards:   "ホールカードを見せられるのは、全員がフォールドして勝ったプレイヤーだけで、ハンドの終了後に1回だけです。",
+	msgChoosingRuns:      "残りのボードを配る回数を選んでいる間はアクションできません。",
+	msgNotChoosingRuns:   "残りのボードを配る回数を選べるのは、全員がオールインしたときにハンドに残っていて、まだ選んでいないプレイヤーだけです。",
+	msgInvalidRunItTimes: "残りのボードを配る回数は1から%d回の間で選んでください。",
+
+	// レイズ
+	msgRaiseCapReached:  "レイズ回数が上限の%d回に達しています。CallかFoldを選択してください。",
+	msgRaiseNotReopened: "このラウンドではレイズできません。CallかFoldを選択してください。",
+	msgRaiseNotAboveBet: "レイズ額は現在のベット額より大きくしてください。",
+	msgRaiseOverStack:   "所持金を超えるレイズはできません。レイズ額は%d以下を指定してください。",
+	msgRaiseOverLimit:   "%sではレイズ額は%d以下を指定してください。",
+	msgRaiseTooSmall:    "レイズ額が足りません。レイズ額は%d以上を指定してください。",
+
+	// キャッシュゲーム
+	msgInvalidBuyInRange: "バイインの下限は正の値で、上限は下限以上を指定してください",
+	msgBuyInOutOfRange:   "バイインは＄%dから＄%dの範囲で指定してください",
+	msgNotCashGame:       "キャッシュゲームではありません",
+	msgNotBetweenHands:   "ハンドの間にだけ行えます",
+	msgLeftPlayerRebuy:   "テーブルを離れたプレイヤーはリバイできません",
+	msgHasChips:          "チップが残っている場合はトップアップしてください",
+	msgLeftPlayerTopUp:   "テーブルを離れたプレイヤーはトップアップできません",
+	msgTopUpOverMax:      "トップアップ後のスタックは＄%d以下にしてください",
+	msgAlreadyMaxBuyIn:   "スタックはすでにバイインの上限です",
+	msgNoEmptySeat:       "空いている座席がありません",
+
+	// 座席
+	msgInvalidSeat:       "座席の指定が不正です: %s",
+	msgInvalidSeatMoney:  "所持金の指定が不正です: %s",
+	msgInvalidController: "操作主体はhumanかbotを指定してください: %s",
+	msgPlayerCount:       "プレイヤー数は%dから%dの間で指定してください",
+	msgNoPlayerName:      "プレイヤー名を指定してください",
+	msgDuplicateName:     "プレイヤー名が重複しています: %s",
+	msgSeatMoneyTooSmall: "プレイヤーの所持金はBBより大きい値を指定してください",
+
+	// トーナメント
+	msgInvalidTournament: "バイインとスタート時のスタックは正の値を指定してください",
+	msgInvalidPayout:     "賞金の割合は正の値を指定してください",
+	msgPayoutTotal:       "賞金の割合の合計が100%%になっていません: %d%%",
+	msgInvalidPayouts:    "賞金の割合の指定が不正です: %s",
+	msgFinalStandings:    "最終順位 (バイイン ＄%d、賞金総額 ＄%d)",
+	msgInterimStandings:  "途中経過 (賞金はゲームの終了時に確定します)",
+
+	// ブラインドストラクチャー
+	msgLoadBlindSchedule:  "ブラインドストラクチャーを読み込めません",
+	msgNoBlindLevels:      "ブラインドストラクチャーにレベルがありません",
+	msgInvalidLevelBlinds: "レベル%d: BBはSBより大きい値を指定してください",
+	msgNegativeLevel:      "レベル%d: 負の値は指定できません",
+	msgLevelLength:        "レベル%d: 時間かハンド数を指定してください",
+
+	// 保存と再開
+	msgLoadSavedGame:         "保存したゲームを読み込めません",
+	msgSavedGameVersion:      "保存したゲームの形式のバージョン%dには対応していません",
+	msgSavedEliminatedSeat:   "敗退したプレイヤーの座席%dがありません",
+	msgLoadSavedEvent:        "保存したイベント%sを読み込めません",
+	msgSavedPlayerCount:      "プレイヤー数は%dから%dの間である必要があります",
+	msgNoSeat:                "座席%dがありません",
+	msgNoBlindLevel:          "ブラインドのレベル%dがありません",
+	msgTooManyCards:          "デッキのカードが多すぎます",
+	msgUnsupportedSavedEvent: "保存したイベント%sには対応していません",
+
+	// ハンドログと再生
+	msgNoEvents:       "イベントがありません",
+	msgNotHandStarted: "最初のイベントがHandStartedではありません",
+	msgBoardNotDealt:  "ボード%dの%sより前のカードがまだ配られていません",
+	msgSeatNotInHand:  "座席%dのプレイヤーはハンドに参加していません",
+	msgNoHands:        "再生するハンドがありません",
+	msgHandOutOfRange: "ハンドは1から%dまでです",
+
+	// イベント
+	msgHandStarted:      "ハンド #%d を開始します。",
+	msgStraddlePosted:   "「%s」が%dでストラドルしました。",
+	msgBlindPosted:      "「%s」が%sの%dを支払いました。",
+	msgHoleCardsDealt:   "%sにカードを配りました。",
+	msgBlindLevelRaised: "ブラインドがレベル%d (%s) に上がりました。",
+	msgTurnStarted:      "次は、%sのアクションです。",
+	msgActionTaken:      "%sは%sを選択しました。",
+	msgFlopDealt:        "フロップを配布します。",
+	msgBoardDealt:       "ボードにカードを追加します。",
+	msgShowdownRevealed: "%s の手役は %s です",
+	msgHandMucked:       "%s は手を見せずに捨てました",
+	msgCardsShown:       "%s がホールカード [%s] を見せました",
+	msgBetReturned:      "「%s」にコールされなかった%dを戻しました",
+	msgPotWon:           "「%s」が%sを獲得しました",
+	msgPotSplit:         "「%s」が%sを分け合いました",
+	msgPotShare:         "獲得ドル: %d",
+	msgMainPot:          "メインポット",
+	msgSidePot:          "サイドポット%d",
+	msgPlayerEliminated: "「%s」が%d位で敗退しました",
+	msgHandEnded:        "ハンド #%d が終了しました。",
+	msgWonAllChips:      "「%s」が全てのチップを獲得しました",
+	msgGameEnded:        "%dハンドでゲームが終了しました",
+	msgRebought:         "「%s」が＄%dでリバイしました",
+	msgToppedUp:         "「%s」が＄%dをトップアップしました",
+	msgSatOut:           "「%s」が離席しました",
+	msgSatIn:            "「%s」が席に戻りました",
+	msgLeft:             "「%s」が＄%dを持ってテーブルを離れました（収支: %s）",
+	msgJoined:           "「%s」が座席%dに着席しました",
+	msgBoardRunDealt:    "ボード%dに [%s] を配りました。",
+	msgRunItOffered:     "全員がオールインしました。残りのボードを配る回数を1から%d回の間で選んでください。",
+	msgRunItAgreed:      "全員がオールインしたため、残りのボードを%d回配ります。",
+	msgOnBoard:          "ボード%d: %s",
+	msgRabbitHunted:     "ラビットハント: 次に配られるはずだったカードは [%s] でした。",
+
+	// 画面
+	msgNextHandPrompt:   "「Next Hand」で次のハンドを開始します。",
+	msgTooFewPlayers:    "プレイヤー数が不足しています。",
+	msgInformationTitle: "情報",
+	msgRaiseToLabel:     "レイズ額: ",
+	msgLevelText:        "レベル%d: %s",
+	msgHandsLeft:        "残り%dハンド",
+	msgYourTurn:         "%sのターンです。アクションを選択してください。",
+	msgWillStraddle:     "%sは次のハンドからストラドルします。",
+	msgStopStraddle:     "%sはストラドルをやめます。",
+	msgWillMuck:         "%sはショーダウンで負けている手を見せずに捨てます。",
+	msgWillShowHands:    "%sはショーダウンで手を全て見せます。",
+	msgActionNotAllowed: "%sは選択できません。",
+	msgRunItTimes:       "%sは残りのボードを%d回配ることを希望しました。",
+	msgRunItOnce:        "%sは残りのボードを1回だけ配ることを希望しました。",
+	msgRunItLabel:       "配る回数: ",
+
+	// リプレイ
+	msgReplayTitle:         "リプレイ",
+	msgReplayPaused:        "一時停止中",
+	msgReplayPlaying:       "再生中",
+	msgReplaySpeed:         "%.1f秒 / ステップ",
+	msgReplayNext:          "進む",
+	msgReplayNextHelp:      "1ステップ進めます (→)",
+	msgReplayBack:          "戻る",
+	msgReplayBackHelp:      "1ステップ戻します (←)",
+	msgReplayPlayPause:     "再生 / 一時停止",
+	msgReplayPlayPauseHelp: "自動で進めます (Space)",
+	msgReplayFaster:        "速く",
+	msgReplayFasterHelp:    "自動で進める間隔を短くします",
+	msgReplaySlower:        "遅く",
+	msgReplaySlowerHelp:    "自動で進める間隔を長くします",
+	msgReplayNextHand:      "次のハンド",
+	msgReplayNextHandHelp:  "次のハンドの最初に移動します",
+	msgReplayPrevHand:      "前のハンド",
+	msgReplayPrevHandHelp:  "前のハンドの最初に移動します",
+	msgReplayQuit:          "終了",
+	msgReplayQuitHelp:      "リプレイを終了します",
+	msgReplayHandTitle:     "ハンド %d / %d",
+	msgReplayStep:          "#%d  %s  ステップ %d / %d",
+	msgReplayMuck:          " (マック)",
+
+	// ハンド履歴の読み込み
+	msgParseErrorLine:          "%d行目: %s: %s",
+	msgSeatAfterBlinds:         "座席の行はブラインドより前に書かれている必要があります",
+	msgUnknownLine:             "解釈できない行です",
+	msgNotHandHeader:           "ハンドの開始行ではありません",
+	msgNotHoldem:               "Hold'em以外のゲームには対応していません",
+	msgInvalidHandNumber:       "ハンド番号が不正です",
+	msgNoBlinds:                "ブラインドが見つかりません",
+	msgNoRaiseTo:               "レイズ後の額がありません",
+	msgUnknownPlayer:           "座席にいないプレイヤーです: %v",
+	msgInvalidAmount:           "額が不正です: %s",
+	msgNthHand:                 "%d番目のハンド (#%s)",
+	msgUnsupportedGame:         "対応していないゲームです: %s",
+	msgUnsupportedBetLimit:     "対応していないベットリミットです: %s",
+	msgTwoPlayersNeeded:        "プレイヤーが2人以上必要です",
+	msgInRound:                 "%sのラウンド",
+	msgInAction:                "アクション%d (%s)",
+	msgPotWinnerNotSeated:      "ポット%dの勝者が座席にいません: %d",
+	msgUnsupportedVariant:      "対応していないバリアントです: %s",
+	msgPlayersMismatch:         "プレイヤーの人数がstarting_stacksと一致しません",
+	msgSeatsMismatch:           "座席の数がstarting_stacksと一致しません",
+	msgActionNotString:         "アクションが文字列ではありません",
+	msgActionTooShort:          "アクションが短すぎます",
+	msgInvalidPlayer:           "プレイヤーが不正です: %s",
+	msgInvalidBoardCount:       "ボードに配るカードの枚数が不正です",
+	msgUnsupportedDealing:      "対応していないディーリングです",
+	msgUnsupportedAction:       "対応していないアクションです: %s",
+	msgInvalidCards:            "カードが不正です: %s",
+	msgNotKeyValue:             "キーと値の組ではありません",
+	msgTrailingCharacters:      "値の後に余分な文字があります",
+	msgDuplicateKey:            "キーが重複しています",
+	msgNoValue:                 "値がありません",
+	msgUnterminatedString:      "文字列が閉じられていません",
+	msgInvalidString:           "文字列が不正です: %s",
+	msgUnterminatedArray:       "配列が閉じられていません",
+	msgNoArraySeparator:        "配列の要素の区切りがありません",
+	msgMissingKey:              "必要なキーがありません",
+	msgNotString:               "文字列ではありません",
+	msgNotNumber:               "数値ではありません",
+	msgNotArray:                "配列ではありません",
+	msgCountMismatch:           "要素の数がstarting_stacksと一致しません",
+	msgPostOverStack:           "「%s」のスタックを超える額を支払っています",
+	msgRaiseNotAbove:           "「%s」のレイズ後の額%dが現在のベット額以下です",
+	msgBetOverStack:            "「%s」のスタックを超える額をベットしています",
+	msgNoBoardCards:            "ボードのカードがありません",
+	msgBoardOverFive:           "ボードのカードが5枚を超えています",
+	msgShowdownCardsUnknown:    "ショーダウンで「%s」のホールカードが分からないため、ポットを分配できません",
+	msgShowdownBoardIncomplete: "ショーダウンでボードのカードが5枚そろっていないため、ポットを分配できません",
+}
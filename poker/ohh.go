@@ -0,0 +1,450 @@
+package poker
+
+import (
+	"encoding/json"
+	"go_poker/card"
+	"go_poker/i18n"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Open Hand History はハンド履歴をJSONで表す形式
+// アクションのamountは、そのアクションで新たに出した額とする
+// ボードを複数回配る場合、2つ目以降のボードは前のラウンドより前のストリートに戻ったラウンドとして書き出す
+
+type ohhDocument struct {
+	OHH ohhHand `json:"ohh"`
+}
+
+type ohhHand struct {
+	SpecVersion      string      `json:"spec_version"`
+	SiteName         string      `json:"site_name"`
+	NetworkName      string      `json:"network_name"`
+	InternalVersion  string      `json:"internal_version"`
+	Tournament       bool        `json:"tournament"`
+	GameNumber       string      `json:"game_number"`
+	StartDateUTC     string      `json:"start_date_utc"`
+	TableName        string      `json:"table_name"`
+	TableSize        int         `json:"table_size"`
+	GameType         string      `json:"game_type"`
+	BetLimit         ohhBetLimit `json:"bet_limit"`
+	Currency         string      `json:"currency"`
+	DealerSeat       int         `json:"dealer_seat"`
+	SmallBlindAmount float64     `json:"small_blind_amount"`
+	BigBlindAmount   float64     `json:"big_blind_amount"`
+	AnteAmount       float64     `json:"ante_amount"`
+	Flags            []string    `json:"flags"`
+	Players          []ohhPlayer `json:"players"`
+	Rounds           []ohhRound  `json:"rounds"`
+	Pots             []ohhPot    `json:"pots"`
+	HuntedCards      []string    `json:"hunted_cards,omitempty"`
+}
+
+type ohhBetLimit struct {
+	BetType string  `json:"bet_type"`
+	BetCap  float64 `json:"bet_cap"`
+}
+
+type ohhPlayer struct {
+	ID            int     `json:"id"`
+	Seat          int     `json:"seat"`
+	Name          string  `json:"name"`
+	Display       string  `json:"display"`
+	StartingStack float64 `json:"starting_stack"`
+}
+
+type ohhRound struct {
+	ID      int         `json:"id"`
+	Street  string      `json:"street"`
+	Cards   []string    `json:"cards,omitempty"`
+	Actions []ohhAction `json:"actions"`
+}
+
+type ohhAction struct {
+	ActionNumber int      `json:"action_number"`
+	PlayerID     int      `json:"player_id"`
+	Action       string   `json:"action"`
+	Amount       float64  `json:"amount"`
+	IsAllIn      bool     `json:"is_allin"`
+	Cards        []string `json:"cards,omitempty"`
+}
+
+type ohhPot struct {
+	Number     int            `json:"number"`
+	Amount     float64        `json:"amount"`
+	Rake       float64        `json:"rake"`
+	PlayerWins []ohhPlayerWin `json:"player_wins"`
+}
+
+type ohhPlayerWin struct {
+	PlayerID        int     `json:"player_id"`
+	WinAmount       float64 `json:"win_amount"`
+	ContributedRake float64 `json:"contributed_rake"`
+}
+
+var ohhBetTypes = map[string]BettingStructure{
+	"NL": NoLimit,
+	"PL": PotLimit,
+	"FL": FixedLimit,
+}
+
+var ohhStreets = map[string]Street{
+	"Flop":  StreetFlop,
+	"Turn":  StreetTurn,
+	"River": StreetRiver,
+}
+
+var ohhBlindActions = map[string]BlindType{
+	"Post SB":          SmallBlindPost,
+	"Post BB":          BigBlindPost,
+	"Post Ante":        AntePost,
+	"Straddle":         StraddlePost,
+	"Post Dead":        AntePost,
+	"Post Extra Blind": AntePost,
+}
+
+func ohhBlindAction(blindType BlindType) string {
+	switch blindType {
+	case SmallBlindPost:
+		return "Post SB"
+	case BigBlindPost:
+		return "Post BB"
+	case StraddlePost:
+		return "Straddle"
+	default:
+		return "Post Ante"
+	}
+}
+
+func ohhCards(cards []card.Card) []string {
+	texts := make([]string, 0, len(cards))
+	for _, c := range cards {
+		texts = append(texts, c.Short())
+	}
+	return texts
+}
+
+// WriteOpenHandHistory は1ハンドのイベントをOpen Hand History形式のJSONとして書き出す
+// heroのホールカードとショーダウンで見せたカードだけを書き出し、heroが空なら分かっている全てのホールカードを書き出す
+// ラビットハントしたカードはOpen Hand Historyで定義されていないため、独自の項目 hunted_cards に書き出す
+func WriteOpenHandHistory(w io.Writer, events []Event, hero string) error {
+	if len(events) == 0 {
+		return ErrNoEvents
+	}
+	started, ok := events[0].(*HandStarted)
+	if !ok {
+		return ErrNotHandStarted
+	}
+	state := NewHandState(started)
+
+	betType := "NL"
+	for name, structure := range ohhBetTypes {
+		if structure == started.Structure {
+			betType = name
+		}
+	}
+	h := ohhHand{
+		SpecVersion:      "1.4.6",
+		SiteName:         "go_poker",
+		NetworkName:      "go_poker",
+		InternalVersion:  "1",
+		GameNumber:       strconv.FormatInt(started.HandID, 10),
+		StartDateUTC:     started.Time.UTC().Format(time.RFC3339),
+		TableName:        "go_poker",
+		TableSize:        started.TableSize,
+		GameType:         "Holdem",
+		BetLimit:         ohhBetLimit{BetType: betType},
+		Currency:         "USD",
+		DealerSeat:       started.Button + 1,
+		SmallBlindAmount: float64(started.SmallBlind),
+		BigBlindAmount:   float64(started.BigBlind),
+		AnteAmount:       float64(started.Ante),
+		Flags:            []string{},
+		Pots:             []ohhPot{},
+	}
+	players := map[int]int{}
+	for i, seat := range started.Seats {
+		players[seat.Seat] = i
+		h.Players = append(h.Players, ohhPlayer{ID: i, Seat: seat.Seat + 1, Name: seat.Name, Display: seat.Name, StartingStack: float64(seat.Stack)})
+	}
+
+	h.Rounds = []ohhRound{{Street: "Preflop", Actions: []ohhAction{}}}
+	addAction := func(a ohhAction) {
+		a.ActionNumber = 1
+		for _, round := range h.Rounds {
+			a.ActionNumber += len(round.Actions)
+		}
+		round := &h.Rounds[len(h.Rounds)-1]
+		round.Actions = append(round.Actions, a)
+	}
+	addRound := func(street string, cards []card.Card) {
+		h.Rounds = append(h.Rounds, ohhRound{ID: len(h.Rounds), Street: street, Cards: ohhCards(cards), Actions: []ohhAction{}})
+	}
+
+	for _, e := range events[1:] {
+		switch e := e.(type) {
+		case *BlindPosted:
+			addAction(ohhAction{PlayerID: players[e.Seat], Action: ohhBlindAction(e.Type), Amount: float64(e.Amount)})
+		case *HoleCardsDealt:
+			a := ohhAction{PlayerID: players[e.Seat], Action: "Dealt Cards"}
+			if hero == "" || e.Name == hero {
+				a.Cards = ohhCards(e.Cards)
+			}
+			addAction(a)
+		case *ActionTaken:
+			seat, err := state.seat(e.Seat)
+			if err != nil {
+				return err
+			}
+			paid := e.Amount - seat.Bet
+			a := ohhAction{PlayerID: players[e.Seat], Amount: float64(paid), IsAllIn: paid > 0 && paid == seat.Stack}
+			switch maxBet := state.maxBet(); {
+			case e.Action.Type == Fold:
+				a.Action = "Fold"
+			case paid == 0:
+				a.Action = "Check"
+			case e.Amount <= maxBet:
+				a.Action = "Call"
+			case maxBet == 0:
+				a.Action = "Bet"
+			default:
+				a.Action = "Raise"
+			}
+			addAction(a)
+		case *StreetDealt:
+			if e.Board > 0 && len(h.Flags) == 0 {
+				h.Flags = append(h.Flags, "Run_It_Twice")
+			}
+			addRound(e.Street.String(), e.Cards)
+		case *ShowdownRevealed:
+			if h.Rounds[len(h.Rounds)-1].Street != "Showdown" {
+				addRound("Showdown", nil)
+			}
+			addAction(ohhAction{PlayerID: players[e.Seat], Action: "Shows Cards", Cards: ohhCards(e.Cards)})
+		case *HandMucked:
+			if h.Rounds[len(h.Rounds)-1].Street != "Showdown" {
+				addRound("Showdown", nil)
+			}
+			addAction(ohhAction{PlayerID: players[e.Seat], Action: "Mucks Cards"})
+		case *CardsShown:
+			addAction(ohhAction{PlayerID: players[e.Seat], Action: "Shows Cards", Cards: ohhCards(e.Cards)})
+		case *PotAwarded:
+			pot := ohhPot{Number: e.PotIndex, Amount: float64(e.Amount), PlayerWins: []ohhPlayerWin{}}
+			for _, share := range e.Shares {
+				pot.PlayerWins = append(pot.PlayerWins, ohhPlayerWin{PlayerID: players[share.Seat], WinAmount: float64(share.Amount)})
+			}
+			h.Pots = append(h.Pots, pot)
+		case *RabbitHunted:
+			h.HuntedCards = ohhCards(e.Cards)
+		}
+		if err := state.Apply(e); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.MarshalIndent(ohhDocument{OHH: h}, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// ReadOpenHandHistories はOpen Hand History形式のJSONを読み込み、ハンドごとのイベントに変換する
+// 1つのファイルに続けて書かれた複数のハンドを読み込める
+// 小数を含む額はセント単位の整数に変換する。解釈できないハンドは読み飛ばし、全てのエラーをParseErrorsとして返す
+func ReadOpenHandHistories(r io.Reader) ([]*HandLog, error) {
+	var logs []*HandLog
+	var errs ParseErrors
+	decoder := json.NewDecoder(r)
+	for index := 1; ; index++ {
+		var doc ohhDocument
+		if err := decoder.Decode(&doc); err == io.EOF {
+			break
+		} else if err != nil {
+			return logs, err
+		}
+		log, err := doc.OHH.hand(len(logs) + 1)
+		if err != nil {
+			errs = append(errs, &ParseError{Text: i18n.T(msgNthHand, index, doc.OHH.GameNumber), Message: err.Error()})
+			continue
+		}
+		logs = append(logs, log)
+	}
+	if len(errs) > 0 {
+		return logs, errs
+	}
+	return logs, nil
+}
+
+// hand はOpen Hand Historyの1ハンドをイベントに変換する
+func (h *ohhHand) hand(handNumber int) (*HandLog, error) {
+	if h.GameType != "Holdem" {
+		return nil, i18n.Errorf(msgUnsupportedGame, h.GameType)
+	}
+	structure, ok := ohhBetTypes[h.BetLimit.BetType]
+	if !ok {
+		return nil, i18n.Errorf(msgUnsupportedBetLimit, h.BetLimit.BetType)
+	}
+	scale := 1
+	for _, v := range []float64{h.SmallBlindAmount, h.BigBlindAmount, h.AnteAmount} {
+		if v != math.Trunc(v) {
+			scale = 100
+		}
+	}
+	amount := func(v float64) int {
+		return int(math.Round(v * float64(scale)))
+	}
+
+	started := &HandStarted{
+		HandNumber:     handNumber,
+		Structure:      structure,
+		SmallBlind:     amount(h.SmallBlindAmount),
+		BigBlind:       amount(h.BigBlindAmount),
+		Ante:           amount(h.AnteAmount),
+		SmallBet:       amount(h.BigBlindAmount),
+		BigBet:         amount(h.BigBlindAmount) * 2,
+		Button:         h.DealerSeat - 1,
+		SmallBlindSeat: -1,
+		BigBlindSeat:   -1,
+		TableSize:      h.TableSize,
+	}
+	started.HandID, _ = strconv.ParseInt(h.GameNumber, 10, 64)
+	started.Time, _ = time.Parse(time.RFC3339, h.StartDateUTC)
+	seats := map[int]int{}
+	for _, player := range h.Players {
+		seats[player.ID] = player.Seat - 1
+		started.Seats = append(started.Seats, HandSeat{Seat: player.Seat - 1, Name: player.Name, Stack: amount(player.StartingStack)})
+		if player.Seat > started.TableSize {
+			started.TableSize = player.Seat
+		}
+	}
+	if len(started.Seats) < 2 {
+		return nil, i18n.Errorf(msgTwoPlayersNeeded)
+	}
+	for _, round := range h.Rounds {
+		for _, a := range round.Actions {
+			switch a.Action {
+			case "Post SB":
+				started.SmallBlindSeat = seats[a.PlayerID]
+			case "Post BB":
+				started.BigBlindSeat = seats[a.PlayerID]
+			}
+		}
+	}
+
+	r := newHandRecorder(started)
+	board, lastStreet := 0, StreetPreFlop
+	for _, round := range h.Rounds {
+		if street, ok := ohhStreets[round.Street]; ok && len(round.Cards) > 0 {
+			// 前のラウンドより前のストリートに戻ったラウンドは次のボードとして扱う
+			if street <= lastStreet {
+				board++
+			}
+			lastStreet = street
+			cards, err := parseOHHCards(round.Cards)
+			if err != nil {
+				return nil, err
+			}
+			if err := r.dealBoard(board, street, cards); err != nil {
+				return nil, i18n.Errorf(msgInRound, round.Street).Wrap(err)
+			}
+		}
+		for _, a := range round.Actions {
+			if err := applyOHHAction(r, a, seats, amount); err != nil {
+				return nil, i18n.Errorf(msgInAction, a.ActionNumber, a.Action).Wrap(err)
+			}
+		}
+	}
+
+	if err := r.returnUncalledBet(); err != nil {
+		return nil, err
+	}
+	for _, pot := range h.Pots {
+		awarded := &PotAwarded{PotIndex: pot.Number, Amount: amount(pot.Amount)}
+		for _, win := range pot.PlayerWins {
+			seat, ok := seats[win.PlayerID]
+			if !ok {
+				return nil, i18n.Errorf(msgPotWinnerNotSeated, pot.Number, win.PlayerID)
+			}
+			s, err := r.state.seat(seat)
+			if err != nil {
+				return nil, err
+			}
+			awarded.Shares = append(awarded.Shares, PotShare{Seat: seat, Name: s.Name, Amount: amount(win.WinAmount)})
+		}
+		if err := r.add(awarded); err != nil {
+			return nil, err
+		}
+	}
+	if len(h.HuntedCards) > 0 {
+		cards, err := parseOHHCards(h.HuntedCards)
+		if err != nil {
+			return nil, err
+		}
+		r.afterHand = append(r.afterHand, &RabbitHunted{Cards: cards})
+	}
+	return r.finish(), nil
+}
+
+// applyOHHAction はOpen Hand Historyのアクションを1つ適用する
+// チップの移動を伴わない着席や会話などのアクションは無視する
+func applyOHHAction(r *handRecorder, a ohhAction, seats map[int]int, amount func(float64) int) error {
+	seat, ok := seats[a.PlayerID]
+	if !ok {
+		return i18n.Errorf(msgUnknownPlayer, a.PlayerID)
+	}
+	if blindType, ok := ohhBlindActions[a.Action]; ok {
+		return r.post(seat, blindType, amount(a.Amount))
+	}
+	switch a.Action {
+	case "Dealt Cards":
+		cards, err := parseOHHCards(a.Cards)
+		if err != nil || len(cards) == 0 {
+			return err
+		}
+		return r.dealHoleCards(seat, cards)
+	case "Fold":
+		return r.fold(seat)
+	case "Check", "Call":
+		return r.checkOrCall(seat)
+	case "Bet", "Raise":
+		s, err := r.state.seat(seat)
+		if err != nil {
+			return err
+		}
+		return r.raiseTo(seat, s.Bet+amount(a.Amount))
+	case "Shows Cards":
+		cards, err := parseOHHCards(a.Cards)
+		if err != nil || len(cards) == 0 {
+			return err
+		}
+		// 全員がフォールドしたあとに見せたカードは、ハンドの終了後に見せたものとして扱う
+		if r.state.lastPlayer() != nil {
+			return r.showAfterHand(seat, cards)
+		}
+		return r.show(seat, cards)
+	case "Mucks Cards":
+		return r.muck(seat)
+	default:
+		return nil
+	}
+}
+
+// parseOHHCards はカードを読み込む。"??" や "Xx" のような分からないカードがあれば何も返さない
+func parseOHHCards(texts []string) ([]card.Card, error) {
+	cards := make([]card.Card, 0, len(texts))
+	for _, text := range texts {
+		if text == "??" || text == "Xx" || text == "xx" {
+			return nil, nil
+		}
+		c, err := card.ParseCard(text)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, c)
+	}
+	return cards, nil
+}
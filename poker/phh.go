@@ -0,0 +1,671 @@
+package poker
+
+import (
+	"errors"
+	"fmt"
+	"go_poker/card"
+	"go_poker/i18n"
+	"io"
+	"io/ioutil"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PHH (Poker Hand History) はTOMLで1ハンドを表す形式
+// プレイヤーはボタンの次の座席から順に p1, p2, ... と呼び、ボタンが最後になる
+// ボードを複数回配る場合、2つ目以降のボードは残りのカードをまとめて1つの "d db" で配る
+
+// phhVariants はPHHのバリアントとベッティングストラクチャーの対応
+// ポットリミット・ホールデムはPHHで定義されていないため、同じ命名規則で "PT" とする
+var phhVariants = map[string]BettingStructure{
+	"NT": NoLimit,
+	"PT": PotLimit,
+	"FT": FixedLimit,
+}
+
+func phhVariant(structure BettingStructure) string {
+	for variant, s := range phhVariants {
+		if s == structure {
+			return variant
+		}
+	}
+	return "NT"
+}
+
+// WritePHHHand は1ハンドのイベントをPHH形式で書き出す
+// heroのホールカードだけを配ったカードとして書き出し、heroが空なら分かっている全てのホールカードを書き出す
+// 書き出さないカードは "????" とし、ショーダウンで見せたカードは "sm" で書き出す
+func WritePHHHand(w io.Writer, events []Event, hero string) error {
+	if len(events) == 0 {
+		return ErrNoEvents
+	}
+	started, ok := events[0].(*HandStarted)
+	if !ok {
+		return ErrNotHandStarted
+	}
+	state := NewHandState(started)
+
+	seats := append([]HandSeat(nil), started.Seats...)
+	fromButton := func(seat int) int {
+		return (seat - started.Button - 1 + 2*started.TableSize) % started.TableSize
+	}
+	sort.Slice(seats, func(i, j int) bool {
+		return fromButton(seats[i].Seat) < fromButton(seats[j].Seat)
+	})
+	players := map[int]int{}
+	for i, seat := range seats {
+		players[seat.Seat] = i
+	}
+
+	antes := make([]int, len(seats))
+	blinds := make([]int, len(seats))
+	holeCards := make([][]card.Card, len(seats))
+	var hunted, shown []card.Card
+	for _, e := range events {
+		switch e := e.(type) {
+		case *BlindPosted:
+			if e.Type == AntePost {
+				antes[players[e.Seat]] += e.Amount
+			} else {
+				blinds[players[e.Seat]] += e.Amount
+			}
+		case *HoleCardsDealt:
+			if hero == "" || e.Name == hero {
+				holeCards[players[e.Seat]] = e.Cards
+			}
+		case *RabbitHunted:
+			hunted = e.Cards
+		case *CardsShown:
+			shown = e.Cards
+		}
+	}
+
+	var actions []string
+	isDealt := false
+	dealHoleCards := func() {
+		if isDealt {
+			return
+		}
+		isDealt = true
+		for i, cards := range holeCards {
+			text := "????"
+			if len(cards) > 0 {
+				text = phhCards(cards)
+			}
+			actions = append(actions, fmt.Sprintf("d dh p%d %s", i+1, text))
+		}
+	}
+	// 2つ目以降のボードのカードはまとめて配るため、他のイベントが来るまで溜めておく
+	runouts := map[int][]card.Card{}
+	flushRunouts := func() {
+		indexes := make([]int, 0, len(runouts))
+		for index := range runouts {
+			indexes = append(indexes, index)
+		}
+		sort.Ints(indexes)
+		for _, index := range indexes {
+			actions = append(actions, "d db "+phhCards(runouts[index]))
+		}
+		runouts = map[int][]card.Card{}
+	}
+
+	for _, e := range events[1:] {
+		if dealt, ok := e.(*StreetDealt); !ok || dealt.Board == 0 {
+			flushRunouts()
+		}
+		switch e := e.(type) {
+		case *HoleCardsDealt:
+			dealHoleCards()
+		case *ActionTaken:
+			dealHoleCards()
+			player := players[e.Seat] + 1
+			seat, err := state.seat(e.Seat)
+			if err != nil {
+				return err
+			}
+			switch {
+			case e.Action.Type == Fold:
+				actions = append(actions, fmt.Sprintf("p%d f", player))
+			case e.Amount <= state.maxBet() || e.Amount == seat.Bet:
+				actions = append(actions, fmt.Sprintf("p%d cc", player))
+			default:
+				actions = append(actions, fmt.Sprintf("p%d cbr %d", player, e.Amount))
+			}
+		case *StreetDealt:
+			if e.Board > 0 {
+				runouts[e.Board] = append(runouts[e.Board], e.Cards...)
+			} else {
+				actions = append(actions, "d db "+phhCards(e.Cards))
+			}
+		case *ShowdownRevealed:
+			actions = append(actions, fmt.Sprintf("p%d sm %s", players[e.Seat]+1, phhCards(e.Cards)))
+		case *HandMucked:
+			actions = append(actions, fmt.Sprintf("p%d sm -", players[e.Seat]+1))
+		}
+		if err := state.Apply(e); err != nil {
+			return err
+		}
+	}
+	flushRunouts()
+
+	names := make([]string, len(seats))
+	seatNumbers := make([]int, len(seats))
+	stacks := make([]int, len(seats))
+	finishingStacks := make([]int, len(seats))
+	for i, seat := range seats {
+		names[i] = strconv.Quote(seat.Name)
+		seatNumbers[i] = seat.Seat + 1
+		stacks[i] = seat.Stack
+		s, err := state.seat(seat.Seat)
+		if err != nil {
+			return err
+		}
+		finishingStacks[i] = s.Stack
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "variant = %q\n", phhVariant(started.Structure))
+	fmt.Fprintf(&b, "antes = %s\n", phhInts(antes))
+	fmt.Fprintf(&b, "blinds_or_straddles = %s\n", phhInts(blinds))
+	if started.Structure == FixedLimit {
+		fmt.Fprintf(&b, "small_bet = %d\n", started.SmallBet)
+		fmt.Fprintf(&b, "big_bet = %d\n", started.BigBet)
+	} else {
+		fmt.Fprintf(&b, "min_bet = %d\n", started.BigBlind)
+	}
+	fmt.Fprintf(&b, "starting_stacks = %s\n", phhInts(stacks))
+	b.WriteString("actions = [\n")
+	for _, action := range actions {
+		fmt.Fprintf(&b, "  %q,\n", action)
+	}
+	b.WriteString("]\n")
+	fmt.Fprintf(&b, "hand = %d\n", started.HandID)
+	fmt.Fprintf(&b, "players = [%s]\n", strings.Join(names, ", "))
+	fmt.Fprintf(&b, "seats = %s\n", phhInts(seatNumbers))
+	fmt.Fprintf(&b, "seat_count = %d\n", started.TableSize)
+	// PHHではボタンを最後のプレイヤー、SBを最初のプレイヤーとするため、空席のボタンとSBはユーザー定義のキーで書き出す
+	if len(seats) > 0 && seats[len(seats)-1].Seat != started.Button {
+		fmt.Fprintf(&b, "_button = %d\n", started.Button+1)
+	}
+	if _, ok := players[started.SmallBlindSeat]; !ok && len(seats) > 2 {
+		b.WriteString("_dead_small_blind = true\n")
+	}
+	// ラビットハントしたカードと、フォールドで勝ったプレイヤーがハンドの終了後に見せたカードは
+	// PHHで定義されていないため、ユーザー定義のキーで書き出す
+	if len(hunted) > 0 {
+		fmt.Fprintf(&b, "_hunted_cards = %q\n", phhCards(hunted))
+	}
+	if len(shown) > 0 {
+		fmt.Fprintf(&b, "_shown_cards = %q\n", phhCards(shown))
+	}
+	b.WriteString("table = \"go_poker\"\n")
+	if !started.Time.IsZero() {
+		t := started.Time.UTC()
+		fmt.Fprintf(&b, "year = %d\nmonth = %d\nday = %d\n", t.Year(), t.Month(), t.Day())
+		fmt.Fprintf(&b, "time = %s\ntime_zone = \"UTC\"\n", t.Format("15:04:05"))
+	}
+	fmt.Fprintf(&b, "finishing_stacks = %s\n", phhInts(finishingStacks))
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func phhCards(cards []card.Card) string {
+	var b strings.Builder
+	for _, c := range cards {
+		b.WriteString(c.Short())
+	}
+	return b.String()
+}
+
+func phhInts(values []int) string {
+	texts := make([]string, 0, len(values))
+	for _, v := range values {
+		texts = append(texts, strconv.Itoa(v))
+	}
+	return "[" + strings.Join(texts, ", ") + "]"
+}
+
+// ReadPHHHands はPHH形式のハンド履歴を読み込み、ハンドごとのイベントに変換する
+// 1ハンドだけのファイル(.phh)と、[1] のような表ごとに1ハンドを持つファイル(.phhs)の両方を読み込める
+// 小数を含む額はセント単位の整数に変換する。解釈できないハンドは読み飛ばし、全てのエラーをParseErrorsとして返す
+func ReadPHHHands(r io.Reader) ([]*HandLog, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := parseTOMLTables(strings.TrimPrefix(string(data), "\uFEFF"))
+	if err != nil {
+		return nil, err
+	}
+	var logs []*HandLog
+	var errs ParseErrors
+	for _, table := range tables {
+		if len(table.fields) == 0 {
+			continue
+		}
+		log, err := table.phhHand(len(logs) + 1)
+		if err != nil {
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				parseErr = &ParseError{Line: table.line, Text: table.name, Message: err.Error()}
+			}
+			errs = append(errs, parseErr)
+			continue
+		}
+		logs = append(logs, log)
+	}
+	if len(errs) > 0 {
+		return logs, errs
+	}
+	return logs, nil
+}
+
+// phhHand はPHHの1ハンド分の表をイベントに変換する
+func (t *tomlTable) phhHand(handNumber int) (*HandLog, error) {
+	variant, err := t.str("variant")
+	if err != nil {
+		return nil, err
+	}
+	structure, ok := phhVariants[variant]
+	if !ok {
+		return nil, t.errorAt("variant", i18n.Errorf(msgUnsupportedVariant, variant))
+	}
+	stacks, err := t.numbers("starting_stacks")
+	if err != nil {
+		return nil, err
+	}
+	count := len(stacks)
+	if count < 2 {
+		return nil, t.errorAt("starting_stacks", i18n.Errorf(msgTwoPlayersNeeded))
+	}
+	antes, err := t.optionalNumbers("antes", count)
+	if err != nil {
+		return nil, err
+	}
+	blinds, err := t.optionalNumbers("blinds_or_straddles", count)
+	if err != nil {
+		return nil, err
+	}
+	scale := 1
+	for _, values := range [][]float64{stacks, antes, blinds} {
+		for _, v := range values {
+			if v != math.Trunc(v) {
+				scale = 100
+			}
+		}
+	}
+	amount := func(v float64) int {
+		return int(math.Round(v * float64(scale)))
+	}
+
+	names := make([]string, count)
+	seats := make([]int, count)
+	for i := range names {
+		names[i] = fmt.Sprintf("p%d", i+1)
+		seats[i] = i
+	}
+	if _, ok := t.fields["players"]; ok {
+		if names, err = t.strings("players"); err != nil {
+			return nil, err
+		}
+		if len(names) != count {
+			return nil, t.errorAt("players", i18n.Errorf(msgPlayersMismatch))
+		}
+	}
+	tableSize := count
+	if _, ok := t.fields["seats"]; ok {
+		values, err := t.numbers("seats")
+		if err != nil {
+			return nil, err
+		}
+		if len(values) != count {
+			return nil, t.errorAt("seats", i18n.Errorf(msgSeatsMismatch))
+		}
+		for i, v := range values {
+			seats[i] = int(v) - 1
+			if seats[i]+1 > tableSize {
+				tableSize = seats[i] + 1
+			}
+		}
+	}
+	if _, ok := t.fields["seat_count"]; ok {
+		v, err := t.number("seat_count")
+		if err != nil {
+			return nil, err
+		}
+		if int(v) > tableSize {
+			tableSize = int(v)
+		}
+	}
+
+	started := &HandStarted{
+		HandNumber:     handNumber,
+		Structure:      structure,
+		Button:         seats[count-1],
+		SmallBlindSeat: -1,
+		BigBlindSeat:   -1,
+		TableSize:      tableSize,
+	}
+	if _, ok := t.fields["hand"]; ok {
+		v, err := t.number("hand")
+		if err != nil {
+			return nil, err
+		}
+		started.HandID = int64(v)
+	}
+	started.Time = t.phhTime()
+	if _, ok := t.fields["_button"]; ok {
+		v, err := t.number("_button")
+		if err != nil {
+			return nil, err
+		}
+		started.Button = int(v) - 1
+	}
+
+	// BBの額は、スタックが足りずにブラインドを一部だけ支払った場合に備えて最低ベット額を優先する
+	bigBlindAmount := math.Max(blinds[0], blinds[1])
+	minBetKey := "min_bet"
+	if structure == FixedLimit {
+		minBetKey = "small_bet"
+	}
+	if _, ok := t.fields[minBetKey]; ok {
+		if bigBlindAmount, err = t.number(minBetKey); err != nil {
+			return nil, err
+		}
+	}
+	started.BigBlind = amount(bigBlindAmount)
+
+	// ブラインドは座席の順に p1 がSB、p2 がBBで、BBより後ろの支払いはストラドルになる
+	// ヘッズアップではボタンの p2 がSBを支払い、p1 がBBになる
+	// SBが空席の場合や、p1 がBB以上を支払っている場合は、p1 がBBになる
+	isDeadSmallBlind := t.fields["_dead_small_blind"].Value == true
+	smallBlind, bigBlind := 0, 1
+	switch {
+	case count == 2:
+		smallBlind, bigBlind = 1, 0
+	case isDeadSmallBlind || blinds[0] >= bigBlindAmount:
+		smallBlind, bigBlind = -1, 0
+	}
+	if smallBlind >= 0 {
+		started.SmallBlindSeat = seats[smallBlind]
+		started.SmallBlind = amount(blinds[smallBlind])
+	}
+	started.BigBlindSeat = seats[bigBlind]
+	if started.SmallBlind == 0 {
+		started.SmallBlind = started.BigBlind / 2
+	}
+	for _, v := range antes {
+		if amount(v) > started.Ante {
+			started.Ante = amount(v)
+		}
+	}
+	if structure == FixedLimit {
+		started.SmallBet, started.BigBet = started.BigBlind, started.BigBlind*2
+		if _, ok := t.fields["small_bet"]; ok {
+			v, err := t.number("small_bet")
+			if err != nil {
+				return nil, err
+			}
+			started.SmallBet = amount(v)
+		}
+		if _, ok := t.fields["big_bet"]; ok {
+			v, err := t.number("big_bet")
+			if err != nil {
+				return nil, err
+			}
+			started.BigBet = amount(v)
+		}
+	}
+	for i := range names {
+		started.Seats = append(started.Seats, HandSeat{Seat: seats[i], Name: names[i], Stack: amount(stacks[i])})
+	}
+
+	r := newHandRecorder(started)
+	// BBアンテはエンジンと同じくBBの後に支払う
+	isBigBlindAnte := antes[bigBlind] > 0
+	for i, v := range antes {
+		if v > 0 && i != bigBlind {
+			isBigBlindAnte = false
+		}
+	}
+	posts := func(err error) error {
+		if err != nil {
+			return t.errorAt("blinds_or_straddles", err)
+		}
+		return nil
+	}
+	if !isBigBlindAnte {
+		for i, v := range antes {
+			if v > 0 {
+				if err := posts(r.post(seats[i], AntePost, amount(v))); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	if smallBlind >= 0 {
+		if err := posts(r.post(seats[smallBlind], SmallBlindPost, amount(blinds[smallBlind]))); err != nil {
+			return nil, err
+		}
+	}
+	if err := posts(r.post(seats[bigBlind], BigBlindPost, amount(blinds[bigBlind]))); err != nil {
+		return nil, err
+	}
+	if isBigBlindAnte {
+		if err := posts(r.post(seats[bigBlind], AntePost, amount(antes[bigBlind]))); err != nil {
+			return nil, err
+		}
+	}
+	if count > 2 {
+		for i := bigBlind + 1; i < count; i++ {
+			if blinds[i] > 0 {
+				if err := posts(r.post(seats[i], StraddlePost, amount(blinds[i]))); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	actions, err := t.array("actions")
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range actions {
+		text, ok := item.Value.(string)
+		if !ok {
+			return nil, &ParseError{Line: item.Line, Text: fmt.Sprint(item.Value), Message: i18n.T(msgActionNotString)}
+		}
+		if err := parsePHHAction(r, text, seats, amount); err != nil {
+			return nil, &ParseError{Line: item.Line, Text: text, Message: err.Error()}
+		}
+	}
+
+	if err := r.returnUncalledBet(); err != nil {
+		return nil, err
+	}
+	if _, ok := t.fields["finishing_stacks"]; ok {
+		finishing, err := t.numbers("finishing_stacks")
+		if err != nil {
+			return nil, err
+		}
+		if len(finishing) != count {
+			return nil, t.errorAt("finishing_stacks", i18n.Errorf(msgPlayersMismatch))
+		}
+		// PHHにはポットごとの分配が書かれないため、スタックの増えた分を1つのポットの獲得額とする
+		awarded := &PotAwarded{}
+		for i, v := range finishing {
+			s, err := r.state.seat(seats[i])
+			if err != nil {
+				return nil, err
+			}
+			if won := amount(v) - s.Stack; won > 0 {
+				awarded.Amount += won
+				awarded.Shares = append(awarded.Shares, PotShare{Seat: s.Seat, Name: s.Name, Amount: won})
+			}
+		}
+		if len(awarded.Shares) > 0 {
+			r.add(awarded)
+		}
+	} else if err := r.awardPots(); err != nil {
+		return nil, t.errorAt("actions", err)
+	}
+	if _, ok := t.fields["_hunted_cards"]; ok {
+		text, err := t.str("_hunted_cards")
+		if err != nil {
+			return nil, err
+		}
+		cards, err := parsePHHCards(text)
+		if err != nil {
+			return nil, t.errorAt("_hunted_cards", err)
+		}
+		r.afterHand = append(r.afterHand, &RabbitHunted{Cards: cards})
+	}
+	if winner := r.state.lastPlayer(); winner != nil {
+		if _, ok := t.fields["_shown_cards"]; ok {
+			text, err := t.str("_shown_cards")
+			if err != nil {
+				return nil, err
+			}
+			cards, err := parsePHHCards(text)
+			if err != nil {
+				return nil, t.errorAt("_shown_cards", err)
+			}
+			r.showAfterHand(winner.Seat, cards)
+		}
+	}
+	return r.finish(), nil
+}
+
+// parsePHHAction は "d dh p1 AcKs" や "p2 cbr 300" のようなPHHのアクションを1つ適用する
+func parsePHHAction(r *handRecorder, text string, seats []int, amount func(float64) int) error {
+	if i := strings.Index(text, "#"); i >= 0 {
+		text = text[:i]
+	}
+	fields := strings.Fields(text)
+	if len(fields) < 2 {
+		return i18n.Errorf(msgActionTooShort)
+	}
+	player := func(text string) (int, error) {
+		n, err := strconv.Atoi(strings.TrimPrefix(text, "p"))
+		if !strings.HasPrefix(text, "p") || err != nil || n < 1 || n > len(seats) {
+			return 0, i18n.Errorf(msgInvalidPlayer, text)
+		}
+		return seats[n-1], nil
+	}
+
+	if fields[0] == "d" {
+		switch {
+		case fields[1] == "dh" && len(fields) == 4:
+			seat, err := player(fields[2])
+			if err != nil {
+				return err
+			}
+			if strings.Contains(fields[3], "?") {
+				return nil
+			}
+			cards, err := parsePHHCards(fields[3])
+			if err != nil {
+				return err
+			}
+			return r.dealHoleCards(seat, cards)
+		case fields[1] == "db" && len(fields) == 3:
+			cards, err := parsePHHCards(fields[2])
+			if err != nil {
+				return err
+			}
+			board := len(r.state.Boards) - 1
+			if board < 0 {
+				board = 0
+			}
+			dealt := len(r.state.Board)
+			if board > 0 {
+				dealt = len(r.state.Boards[board])
+			}
+			// 配り終えたボードにカードを配る場合は、残りのカードをまとめて配った次のボードとして扱う
+			if dealt == 5 {
+				board++
+				dealt = 5 - len(cards)
+			}
+			street, ok := streetForBoardCards(dealt)
+			if !ok {
+				return i18n.Errorf(msgInvalidBoardCount)
+			}
+			return r.dealBoard(board, street, cards)
+		default:
+			return i18n.Errorf(msgUnsupportedDealing)
+		}
+	}
+
+	seat, err := player(fields[0])
+	if err != nil {
+		return err
+	}
+	switch {
+	case fields[1] == "f":
+		return r.fold(seat)
+	case fields[1] == "cc":
+		return r.checkOrCall(seat)
+	case fields[1] == "cbr" && len(fields) == 3:
+		v, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil {
+			return i18n.Errorf(msgInvalidAmount, fields[2])
+		}
+		return r.raiseTo(seat, amount(v))
+	case fields[1] == "sm":
+		if len(fields) < 3 || fields[2] == "-" {
+			return r.muck(seat)
+		}
+		// 分からないカードを見せた場合はハンドの状態に含めない
+		if strings.Contains(fields[2], "?") {
+			return nil
+		}
+		cards, err := parsePHHCards(fields[2])
+		if err != nil {
+			return err
+		}
+		return r.show(seat, cards)
+	default:
+		return i18n.Errorf(msgUnsupportedAction, fields[1])
+	}
+}
+
+// parsePHHCards は "AcKs" のように区切りなしで並んだカードを読み込む
+func parsePHHCards(text string) ([]card.Card, error) {
+	if len(text)%2 != 0 {
+		return nil, i18n.Errorf(msgInvalidCards, text)
+	}
+	cards := make([]card.Card, 0, len(text)/2)
+	for i := 0; i < len(text); i += 2 {
+		c, err := card.ParseCard(text[i : i+2])
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, c)
+	}
+	return cards, nil
+}
+
+// phhTime はyear, month, day, time, time_zoneからハンドの開始時刻を返す
+func (t *tomlTable) phhTime() time.Time {
+	year, errYear := t.number("year")
+	month, errMonth := t.number("month")
+	day, errDay := t.number("day")
+	if errYear != nil || errMonth != nil || errDay != nil {
+		return time.Time{}
+	}
+	location := time.UTC
+	if name, err := t.str("time_zone"); err == nil {
+		if loc, err := time.LoadLocation(name); err == nil {
+			location = loc
+		}
+	}
+	var clock time.Time
+	if text, err := t.str("time"); err == nil {
+		clock, _ = time.Parse("15:04:05", text)
+	}
+	return time.Date(int(year), time.Month(month), int(day), clock.Hour(), clock.Minute(), clock.Second(), 0, location)
+}
@@ -1,7 +1,6 @@
 package poker
 
 import (
-	"errors"
 	"fmt"
 	"go_poker/hand"
 	"strconv"
@@ -11,25 +10,71 @@ type Player struct {
 	Name          string
 	Hand          hand.Hand
 	Position      Position
+	Controller    Controller
 	Money         int
+	BuyIn         int
 	CurrentBet    int
+	TotalBet      int
+	AntePaid      int
 	CurrentAction Action
+	ActedBet      int
+	HasActed      bool
 	IsHandWin     bool
+	IsBusted      bool
+	IsSittingOut  bool
+	HasLeft       bool
+	WantsStraddle bool
+	RunItTimes    int
+	MucksLosing   bool
 }
 
-func NewPlayer(name string, initMoney int, position Position) *Player {
+func NewPlayer(name string, initMoney int, position Position, controller Controller) *Player {
 	return &Player{
-		Name:     name,
-		Money:    initMoney,
-		Position: position,
+		Name:       name,
+		Money:      initMoney,
+		BuyIn:      initMoney,
+		Position:   position,
+		Controller: controller,
 	}
 }
 
+func (p *Player) IsBot() bool {
+	return p.Controller == Bot
+}
+
+// isDealtIn はチップがあり、離席もテーブルを離れてもおらず、カードを配られるかを返す
+func (p *Player) isDealtIn() bool {
+	return !p.IsBusted && !p.IsSittingOut && !p.HasLeft
+}
+
+// isInHand はカードを配られてフォールドしておらず、ハンドに参加しているかを返す
+func (p *Player) isInHand() bool {
+	return p.isDealtIn() && p.CurrentAction.Type != Fold
+}
+
+// isAllIn はハンドに参加していて、チップを全てベットしたかを返す
+func (p *Player) isAllIn() bool {
+	return p.isInHand() && p.Money == 0
+}
+
+// wantsMuck はショーダウンで負けている手を見せずに捨てるかを返す。Botは常に捨てる
+func (p *Player) wantsMuck() bool {
+	return p.IsBot() || p.MucksLosing
+}
+
+// canAction はフォールドもオールインもしておらず、アクションできるかを返す
+func (p *Player) canAction() bool {
+	return p.isInHand() && p.Money > 0
+}
+
 func (p *Player) NextHand() *Player {
 	p.Hand = hand.Hand{}
-	p.Position = p.Position.Next()
 	p.CurrentBet = 0
-	p.CurrentAction = Action{Type: Check}
+	p.TotalBet = 0
+	p.AntePaid = 0
+	p.CurrentAction = Action{}
+	p.ActedBet = 0
+	p.HasActed = false
 	p.IsHandWin = false
 	return p
 }
@@ -37,28 +82,72 @@ func (p *Player) NextHand() *Player {
 func (p *Player) Win(addMoney int) {
 	p.Money += addMoney
 	p.CurrentBet = 0
+	p.TotalBet = 0
+	p.AntePaid = 0
 	p.IsHandWin = true
 }
 
 func (p *Player) Bet(betMoney int) error {
 	if p.Money < betMoney {
-		return errors.New("ベット額が足りません")
+		return ErrNotEnoughMoney
 	}
 	p.Money -= betMoney
 	p.CurrentBet += betMoney
+	p.TotalBet += betMoney
 	return nil
 }
 
 func (p *Player) Lose() error {
 	p.CurrentBet = 0
+	p.TotalBet = 0
+	p.AntePaid = 0
 	return nil
 }
 
+// PostBlind はブラインドを支払う。所持金が足りない場合はオールインになる
+func (p *Player) PostBlind(blind int) *Player {
+	if p.Money <= blind {
+		return p.AllIn()
+	}
+	p.Bet(blind)
+	return p
+}
+
+// PostAnte はアンテを支払う。アンテはポットに入るがベット額には含まれない
+func (p *Player) PostAnte(ante int) *Player {
+	if ante > p.Money {
+		ante = p.Money
+	}
+	p.Money -= ante
+	p.TotalBet += ante
+	p.AntePaid += ante
+	return p
+}
+
+// liveBet はハンド全体での拠出額のうち、アンテを除いたベット額を返す
+func (p *Player) liveBet() int {
+	return p.TotalBet - p.AntePaid
+}
+
 func (p *Player) AllIn() *Player {
 	p.Bet(p.Money)
 	return p
 }
 
+// NetResult はテーブルに持ち込んだ額からの収支を返す
+func (p Player) NetResult() int {
+	return p.Money - p.BuyIn
+}
+
+func (p Player) GetNetResultString() string {
+	if p.NetResult() > 0 {
+		return "+＄" + strconv.Itoa(p.NetResult())
+	} else if p.NetResult() < 0 {
+		return "-＄" + strconv.Itoa(-p.NetResult())
+	}
+	return "＄0"
+}
+
 func (p Player) GetMoneyString() string {
 	return "＄" + strconv.Itoa(p.Money)
 }
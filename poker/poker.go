@@ -1,189 +1,370 @@
 package poker
 
 import (
-	"errors"
 	"fmt"
-	"github.com/rivo/tview"
 	"go_poker/card"
 	"go_poker/deck"
-	"go_poker/hand"
-	"math/rand"
 	"strconv"
 	"time"
 )
 
 type Poker struct {
-	Players         []*Player
-	Deck            *deck.Deck
-	BigBlind        int
-	SmollBlind      int
-	Pot             int
-	Flop            []card.Card
-	TurnIndex       int
-	TurnBet         int
-	IsHandFinished  bool
-	InfomationTexts []string
-	Viewer          Viewer
+	Players          []*Player
+	Deck             *deck.Deck
+	Rand             *deck.Source
+	BigBlind         int
+	SmollBlind       int
+	Ante             int
+	BigBlindAnte     bool
+	Straddle         StraddleType
+	MaxReStraddles   int
+	StraddleIndexes  []int
+	Schedule         *BlindSchedule
+	Level            int
+	LevelStartedAt   time.Time
+	LevelStartedHand int
+	Tournament       *Tournament
+	CashGame         *CashGame
+	Pot              int
+	Flop             []card.Card
+	Runouts          [][]card.Card
+	Hunted           []card.Card
+	MaxRuns          int
+	Street           Street
+	Structure        BettingStructure
+	SmallBet         int
+	BigBet           int
+	RaiseCap         int
+	RaiseCount       int
+	OddChipRule      OddChipRule
+	ChipUnit         int
+	Button           int
+	SmallBlindIndex  int
+	BigBlindIndex    int
+	TurnIndex        int
+	TurnBet          int
+	LastRaise        int
+	// RoundAggressorIndex は現在のベッティングラウンドで最後にベットかレイズをしたプレイヤーの座席で、いなければ-1
+	RoundAggressorIndex int
+	// LastAggressorIndex はアクションのあった最後のラウンドのRoundAggressorIndexで、ショーダウンで最初に手を見せるプレイヤーを決める
+	LastAggressorIndex int
+	IsHandFinished     bool
+	IsChoosingRuns     bool
+	HandCount          int
+	InfomationTexts    []string
+	Listeners          []Listener
+	HandLogs           []*HandLog
 }
 
-func NewPoker(bb, sb, playerInitMoney int) *Poker {
+func NewPoker(bb, sb int, seats []Seat) *Poker {
 	if bb < sb {
-		fmt.Println("BBはSBより大きい値を指定してください")
+		fmt.Println(ErrInvalidBlinds)
 		return nil
-	} else if playerInitMoney < bb {
-		fmt.Println("プレイヤーの所持金はBBより大きい値を指定してください")
+	} else if err := validateSeats(seats, bb); err != nil {
+		fmt.Println(err)
 		return nil
 	}
-	d := deck.NewDeck().Shuffle()
+	src := deck.NewTimeSource()
+	d := deck.NewDeck().ShuffleWith(src)
+
+	players := make([]*Player, 0, len(seats))
+	for _, seat := range seats {
+		players = append(players, NewPlayer(seat.Name, seat.Money, 0, seat.Controller))
+	}
 
 	p := &Poker{
-		Players: []*Player{
-			NewPlayer("Player", playerInitMoney, SB),
-			NewPlayer("Enemy", playerInitMoney, BB),
-		},
+		Players:    players,
 		Deck:       d,
+		Rand:       src,
 		BigBlind:   bb,
 		SmollBlind: sb,
+		SmallBet:   bb,
+		BigBet:     bb * 2,
+		RaiseCap:   4,
+		ChipUnit:   1,
 	}
-	p.Viewer = Viewer{
-		Context: p,
-		App:     tview.NewApplication(),
-	}
+	p.initButton(0)
 
 	return p
 }
 
-func (p *Poker) InitSetUp() {
+// StartHand はブラインドを支払いカードを配って、新しいハンドを開始する
+func (p *Poker) StartHand() {
+	p.HandCount++
+	p.updateBlindLevel(time.Now())
+	p.emit(p.handStarted())
+	if p.Tournament != nil {
+		p.Tournament.recordHandStart(p.Players)
+	}
+	// ブラインドとストラドルはアグレッサーに数えない
+	p.RoundAggressorIndex = -1
+	p.LastAggressorIndex = -1
+
 	// ブラインドベット
 	p.BlindBet()
 	// プリフロップ
 	p.PreFlop()
+	p.TurnIndex = p.firstActionIndex()
 
-	// TUIに描画
-	err := p.Viewer.DrawInit()
-	if err != nil {
-		panic(err)
+	// 最初の手番がBotならアクションさせる
+	if p.isRoundClosed() {
+		p.NextStreet()
+	} else {
+		p.emit(&TurnStarted{Seat: p.TurnIndex, Name: p.getCurrentPlayer().Name})
+		p.playBot()
 	}
 }
 
-func (p *Poker) BlindBet() *Poker {
-	for _, player := range p.Players {
-		if player.Position == SB {
-			player.Bet(p.SmollBlind)
-		} else if player.Position == BB {
-			player.Bet(p.BigBlind)
+// NextHand は終了したハンドを片付け、ボタンを進めて次のハンドを開始する
+// チップを持つプレイヤーが1人になるとゲームは終了する
+func (p *Poker) NextHand() error {
+	if !p.IsHandFinished {
+		return ErrHandNotFinished
+	}
+	if p.IsGameOver() {
+		return ErrGameOver
+	}
+	if p.CashGame != nil {
+		p.rebuyBots()
+		if p.countReadyPlayers() < 2 {
+			return ErrNotEnoughPlayers
 		}
 	}
-	p.TurnBet = p.BigBlind
-	return p
-}
 
-func (p *Poker) PreFlop() *Poker {
 	for _, player := range p.Players {
-		player.Hand.Add(p.Deck.Deal(2))
+		player.NextHand()
 	}
-	return p
+	p.MoveButton()
+	p.Deck = deck.NewDeck().ShuffleWith(p.source())
+	p.Flop = nil
+	p.Runouts = nil
+	p.Hunted = nil
+	p.IsHandFinished = false
+	p.StartHand()
+	return nil
 }
 
-func (p *Poker) isNextTurn() bool {
-	for i := 0; i < len(p.Players); i++ {
-		if i >= len(p.Players)-1 {
-			return true
-		}
-		if p.Players[i].CurrentBet != p.Players[i+1].CurrentBet {
-			return false
+// IsGameOver はチップを持つプレイヤーが1人以下になったかを返す
+// キャッシュゲームではリバイや新しいプレイヤーの着席ができるため終了しない
+// ハンドの進行中は、オールインしたプレイヤーがポットを取り戻せるため終了しない
+func (p *Poker) IsGameOver() bool {
+	if p.CashGame != nil || !p.IsHandFinished {
+		return false
+	}
+	count := 0
+	for _, player := range p.Players {
+		if !player.IsBusted && player.Money > 0 {
+			count++
 		}
 	}
-	return true
+	return count < 2
 }
 
-func (p *Poker) NextTurn() error {
-	notFoldPlayers := p.getNotFoldPlayers()
-
-	if !p.isNextTurn() && len(notFoldPlayers) >= 2 {
-		return errors.New("プレイヤーのベット額が一致していません")
+// BlindBet はアンテとブラインド、ストラドルを支払わせる
+// アンテはデッドマネーとしてポットに入り、ブラインドとストラドルはベット額に含まれる
+func (p *Poker) BlindBet() *Poker {
+	if p.Ante > 0 && !p.BigBlindAnte {
+		for i, player := range p.Players {
+			if player.isDealtIn() {
+				p.postBlind(i, AntePost, p.Ante)
+			}
+		}
 	}
+	// デッドSBの場合はSBを支払わない
+	if !p.IsDeadSmallBlind() {
+		p.postBlind(p.SmallBlindIndex, SmallBlindPost, p.SmollBlind)
+	}
+	p.postBlind(p.BigBlindIndex, BigBlindPost, p.BigBlind)
+	// BBアンテはブラインドを優先して支払う
+	if p.Ante > 0 && p.BigBlindAnte {
+		p.postBlind(p.BigBlindIndex, AntePost, p.Ante)
+	}
+	p.TurnBet = p.BigBlind
+	p.LastRaise = p.initialRaise()
+	// BBを最初のベットとして数える
+	p.RaiseCount = 1
+	p.postStraddles()
+	return p
+}
 
-	// どちらかがフォールドした場合
-	if len(notFoldPlayers) == 1 {
-		notFoldPlayers[0].IsHandWin = true
-		p.Finish()
-		return nil
+// postBlind は座席のプレイヤーにブラインドやアンテを支払わせ、実際に支払った額を記録する
+func (p *Poker) postBlind(index int, blindType BlindType, amount int) {
+	player := p.Players[index]
+	before := player.Money
+	switch blindType {
+	case AntePost:
+		player.PostAnte(amount)
+	case StraddlePost:
+		player.Bet(amount)
+	default:
+		player.PostBlind(amount)
 	}
+	p.emit(&BlindPosted{Seat: index, Name: player.Name, Type: blindType, Amount: before - player.Money})
+}
 
-	if len(p.Flop) >= 5 {
-		p.ShowDown()
-	} else {
-		p.OpenFlop()
+// handStarted はハンド開始時のボタンとブラインド、カードを配られるプレイヤーのスタックを表すイベントを作成する
+func (p *Poker) handStarted() *HandStarted {
+	e := &HandStarted{
+		HandNumber:     p.HandCount,
+		HandID:         time.Now().Unix()*10000 + int64(p.HandCount%10000),
+		Structure:      p.Structure,
+		SmallBlind:     p.SmollBlind,
+		BigBlind:       p.BigBlind,
+		Ante:           p.Ante,
+		SmallBet:       p.SmallBet,
+		BigBet:         p.BigBet,
+		Button:         p.Button,
+		SmallBlindSeat: p.SmallBlindIndex,
+		BigBlindSeat:   p.BigBlindIndex,
+		TableSize:      len(p.Players),
+	}
+	for i, player := range p.Players {
+		if player.isDealtIn() {
+			e.Seats = append(e.Seats, HandSeat{Seat: i, Name: player.Name, Position: player.Position, Stack: player.Money})
+		}
 	}
-	p.TurnIndex = 0
-	p.Viewer.DrawByCurrentData()
+	return e
+}
 
-	return nil
+func (p *Poker) PreFlop() *Poker {
+	p.Street = StreetPreFlop
+	for i, player := range p.Players {
+		if !player.isDealtIn() {
+			continue
+		}
+		player.Hand.Add(p.Deck.Deal(2))
+		p.emit(&HoleCardsDealt{Seat: i, Name: player.Name, Cards: append([]card.Card(nil), player.Hand.Cards...)})
+	}
+	return p
 }
 
+// Finish はメインポットとサイドポットをそれぞれの勝者に分配してハンドを終了する
 func (p *Poker) Finish() {
-	losePlayerIndex := 0
-	for i, player := range p.Players {
-		if player.IsHandWin {
-			getMoney := p.CulcPot() - player.CurrentBet
-			player.Win(p.CulcPot())
-			p.Viewer.WriteInfoText(fmt.Sprintf("「%s」の勝利です", player.Name))
-			p.Viewer.WriteInfoText(fmt.Sprintf("獲得ドル: %s", strconv.Itoa(getMoney)))
-		} else {
-			losePlayerIndex = i
+	p.awardPots()
+	for _, player := range p.Players {
+		if !player.IsHandWin {
+			player.Lose()
+		}
+	}
+	if p.Tournament != nil {
+		for _, player := range p.Tournament.recordEliminations(p.Players) {
+			p.emit(&PlayerEliminated{Player: player, Place: p.Tournament.eliminatedPlace(player, len(p.Players))})
+		}
+	}
+	p.IsHandFinished = true
+	p.emit(&HandEnded{HandNumber: p.HandCount, IsGameOver: p.IsGameOver()})
+	if p.IsGameOver() {
+		p.emit(&GameEnded{HandCount: p.HandCount, Players: p.Players, Tournament: p.Tournament})
+	}
+}
+
+// awardPots はメインポットとサイドポットをそれぞれの勝者に分配する
+// 同じ強さの手役が複数あればポットを等分し、端数はOddChipRuleに従って配る
+// ボードを複数回配った場合は、それぞれのポットをボードの数で等分し、ボードごとの勝者に分配する
+// 1人しか獲得できないポットは等分せず、最初のボードで分配する
+func (p *Poker) awardPots() {
+	boards := p.Boards()
+	pots := p.Pots()
+	amounts := make([][]int, len(pots))
+	for i, pot := range pots {
+		amounts[i] = []int{pot.Amount}
+		if len(pot.Eligibles) > 1 {
+			amounts[i] = p.splitRuns(pot.Amount, len(boards))
+		}
+	}
+	for board := range boards {
+		if len(boards) > 1 {
+			for _, player := range p.getNotFoldPlayers() {
+				player.Hand.Culc(boards[board])
+			}
+		}
+		for i, pot := range pots {
+			if board >= len(amounts[i]) {
+				continue
+			}
+			winPlayers := p.judgePotWinners(pot)
+			shares := p.splitPot(amounts[i][board], winPlayers)
+			awarded := &PotAwarded{Board: board, PotIndex: i, Amount: amounts[i][board]}
+			for j, winPlayer := range winPlayers {
+				winPlayer.Win(shares[j])
+				awarded.Shares = append(awarded.Shares, PotShare{Seat: p.seatIndex(winPlayer), Name: winPlayer.Name, Amount: shares[j]})
+			}
+			p.emit(awarded)
 		}
 	}
-	p.Players[losePlayerIndex].Lose()
-	p.Viewer.DrawByCurrentData()
+}
+
+// Pots は現在のメインポットとサイドポットを返す
+func (p *Poker) Pots() []Pot {
+	return BuildPots(p.Players)
 }
 
 func (p *Poker) CulcPot() (result int) {
 	for _, player := range p.Players {
-		result += player.CurrentBet
+		result += player.TotalBet
 	}
 	return result
 }
 
+// judgePotWinners はポットに参加しているプレイヤーの中で最も強い手役のプレイヤーを返す
+// 同じ強さの手役が複数あれば全員を返す
+func (p *Poker) judgePotWinners(pot Pot) []*Player {
+	winPlayers := []*Player{pot.Eligibles[0]}
+	for _, player := range pot.Eligibles[1:] {
+		switch diff := player.Hand.Compare(&winPlayers[0].Hand); {
+		case diff > 0:
+			winPlayers = []*Player{player}
+		case diff == 0:
+			winPlayers = append(winPlayers, player)
+		}
+	}
+	return winPlayers
+}
+
 func (p *Poker) OpenFlop() {
 	openFlopCount := 1
 	if len(p.Flop) == 0 {
 		openFlopCount = 3
-		p.Viewer.WriteInfoText("フロップを配布します。")
-	} else {
-		p.Viewer.WriteInfoText("ボードにカードを追加します。")
 	}
-	p.Flop = append(p.Flop, p.Deck.Deal(openFlopCount)...)
+	cards := p.Deck.Deal(openFlopCount)
+	p.Flop = append(p.Flop, cards...)
+	p.emit(&StreetDealt{Street: p.Street, Cards: cards})
 }
 
+// ShowDown は最後のラウンドで最後にベットかレイズをしたプレイヤーから順に手を見せ、ポットを分配する
+// 後から見せるプレイヤーは、負けている手を見せずに捨てられる
 func (p *Poker) ShowDown() *Poker {
-	winPlayer := p.Players[0]
-	for _, player := range p.Players {
+	var shown []*Player
+	for _, player := range p.showdownOrder() {
 		player.Hand.Culc(p.Flop)
-		p.Viewer.WriteInfoText(fmt.Sprintf("%s の手役は %s です", player.Name, player.Hand.Point))
-		if winPlayer.Hand.Point < player.Hand.Point {
-			winPlayer = player
-		} else if winPlayer.Hand.Point == player.Hand.Point {
-			winPlayer = p.JudgeWinPlayerFromDrawHand(winPlayer, player)
+		if player.wantsMuck() && p.canMuck(player, shown) {
+			p.emit(&HandMucked{Seat: p.seatIndex(player), Name: player.Name})
+			continue
 		}
+		shown = append(shown, player)
+		p.emit(&ShowdownRevealed{Seat: p.seatIndex(player), Name: player.Name, Cards: append([]card.Card(nil), player.Hand.Cards...), Point: player.Hand.Point})
 	}
-	winPlayer.IsHandWin = true
 	p.Finish()
-	if len(p.Players) >= 2 {
-		p.Viewer.OpenEnemyCards(p.Players[1].GetHandStrings())
-	}
 	return p
 }
 
 func (p *Poker) Action(a Action) error {
+	if p.IsHandFinished {
+		return ErrHandFinished
+	}
+	if p.IsChoosingRuns {
+		return ErrChoosingRuns
+	}
 	turnPlayer := p.getCurrentPlayer()
-	turnPlayer.CurrentAction = a
 
 	switch a.Type {
 	case Call:
 		diff := p.TurnBet - turnPlayer.CurrentBet
 		if diff == 0 {
-			return errors.New("ベット額が既に足りています。RaiseかCheckを選択してください。")
+			return ErrNothingToCall
+		} else if diff >= turnPlayer.Money {
+			// 所持金が足りない場合はオールインでコールする
+			turnPlayer.AllIn()
 		} else {
 			err := turnPlayer.Bet(diff)
 			if err != nil {
@@ -194,9 +375,12 @@ func (p *Poker) Action(a Action) error {
 	case Check:
 		diff := p.TurnBet - turnPlayer.CurrentBet
 		if diff > 0 {
-			return errors.New("ベット額が足りていません。CallかRaiseを選択してください。")
+			return ErrCannotCheck
 		}
 	case Raise:
+		if err := p.validateRaise(turnPlayer, a.Bet); err != nil {
+			return err
+		}
 		diff := a.Bet - turnPlayer.CurrentBet
 		err := turnPlayer.Bet(diff)
 		if err != nil {
@@ -204,93 +388,118 @@ func (p *Poker) Action(a Action) error {
 		}
 		//p.Pot += diff
 	case AllIn:
+		// ポットリミットやフィックスドリミットでは、上限を超えるオールインはできない
+		if allInBet := turnPlayer.CurrentBet + turnPlayer.Money; allInBet > p.TurnBet {
+			if err := p.validateRaise(turnPlayer, allInBet); err != nil {
+				return err
+			}
+		}
 		turnPlayer.AllIn()
 	}
-	p.TurnBet = turnPlayer.CurrentBet
+	turnPlayer.CurrentAction = a
+	turnPlayer.HasActed = true
+	if turnPlayer.CurrentBet > p.TurnBet {
+		// フルレイズであれば最低レイズ額を更新する
+		// 最低レイズ額に満たないオールインでは、アクション済みのプレイヤーにレイズの権利は戻らない
+		if raiseSize := turnPlayer.CurrentBet - p.TurnBet; raiseSize >= p.LastRaise {
+			p.LastRaise = raiseSize
+			p.RaiseCount++
+		}
+		// レイズされたら他のプレイヤーは再びアクションする必要がある
+		p.TurnBet = turnPlayer.CurrentBet
+		for _, player := range p.Players {
+			if player != turnPlayer {
+				player.HasActed = false
+			}
+		}
+		p.RoundAggressorIndex = p.TurnIndex
+	}
+	p.LastAggressorIndex = p.RoundAggressorIndex
+	turnPlayer.ActedBet = p.TurnBet
+	p.emit(&ActionTaken{Seat: p.TurnIndex, Name: turnPlayer.Name, Action: a, Amount: turnPlayer.CurrentBet})
 	return nil
 }
 
 func (p *Poker) NextPlayer() *Poker {
-	if p.TurnIndex < len(p.Players)-1 {
-		p.TurnIndex += 1
-	} else {
-		p.TurnIndex = 0
+	if p.IsHandFinished {
+		return p
 	}
-
-	cp := p.getCurrentPlayer()
-	p.Viewer.WriteInfoText(fmt.Sprintf("次は、%sのアクションです。", cp.Name))
-
-	if cp.Name == "Enemy" {
-		a := p.RandomAction()
-		cp.CurrentAction = a
-		p.Viewer.WriteInfoText(fmt.Sprintf("%sは%sを選択しました。", cp.Name, a.Type))
-		if a.Type == Fold {
-			p.NextTurn()
-			return p
-		}
-		if p.isNextTurn() {
-			p.Viewer.WriteInfoText(fmt.Sprintf("次のターンへ進みます。"))
-			p.NextTurn()
-			return p
-		}
-
-		switch a.Type {
-		case Raise:
-			diff := a.Bet - cp.CurrentBet
-			cp.Bet(diff)
-			p.TurnBet = cp.CurrentBet
-			p.NextPlayer()
-		case AllIn:
-			cp.AllIn()
-			p.TurnBet = cp.CurrentBet
-			p.NextPlayer()
-		case Call:
-			diff := p.TurnBet - cp.CurrentBet
-			cp.Bet(diff)
-			p.NextTurn()
-		}
+	if len(p.getNotFoldPlayers()) == 1 {
+		p.NextStreet()
+		return p
 	}
+	if p.isRoundClosed() {
+		p.NextStreet()
+		return p
+	}
+
+	p.TurnIndex = p.nextActionIndex(p.TurnIndex)
+	p.emit(&TurnStarted{Seat: p.TurnIndex, Name: p.getCurrentPlayer().Name})
+	p.playBot()
 	return p
 }
 
-func (p *Poker) JudgeWinPlayerFromDrawHand(playerA, playerB *Player) *Player {
-	switch playerA.Hand.Point {
-	case hand.AFullHouse:
-		playerAThreeCardNumber := playerA.Hand.GetHandInnerThreeCardGroup()[0].Number
-		playerBThreeCardNumber := playerB.Hand.GetHandInnerThreeCardGroup()[0].Number
-		if playerAThreeCardNumber.IsLarge(playerBThreeCardNumber) {
-			return playerA
-		} else {
-			return playerB
-		}
-	// 役とマークの判定を追加する
-	default:
-		return playerA
+// playBot は手番のプレイヤーがBotであればアクションを選択させる
+func (p *Poker) playBot() {
+	cp := p.getCurrentPlayer()
+	if p.IsHandFinished || !cp.IsBot() {
+		return
 	}
+
+	if err := p.Action(p.RandomAction()); err != nil {
+		p.Action(Action{Type: Fold})
+	}
+	p.NextPlayer()
 }
 
 func (p *Poker) GetPotString() string {
 	return "＄" + strconv.Itoa(p.CulcPot())
 }
 
+// seatIndex はプレイヤーの座席の番号を返す
+func (p *Poker) seatIndex(player *Player) int {
+	for i, seated := range p.Players {
+		if seated == player {
+			return i
+		}
+	}
+	return -1
+}
+
 func (p *Poker) getCurrentPlayer() *Player {
 	return p.Players[p.TurnIndex]
 }
 
 func (p *Poker) getNextPlayer() *Player {
-	idx := p.TurnIndex
-	if p.TurnIndex < len(p.Players)-1 {
-		idx += 1
-	} else {
-		idx = 0
+	return p.Players[p.nextActionIndex(p.TurnIndex)]
+}
+
+// nextActionIndex は指定した座席の次にアクションできるプレイヤーの座席を返す
+func (p *Poker) nextActionIndex(from int) int {
+	for i := 1; i <= len(p.Players); i++ {
+		idx := (from + i) % len(p.Players)
+		if p.Players[idx].canAction() {
+			return idx
+		}
 	}
-	return p.Players[idx]
+	return from
 }
 
 func (p *Poker) getNotFoldPlayers() []*Player {
 	results := make([]*Player, 0, len(p.Players))
 	for _, player := range p.Players {
-		if player.CurrentAction.Type != Fold {
+		if player.isInHand() {
+			results = append(results, player)
+		}
+	}
+	return results
+}
+
+// getActionablePlayers はフォールドもオールインもしていないプレイヤーを返す
+func (p *Poker) getActionablePlayers() []*Player {
+	results := make([]*Player, 0, len(p.Players))
+	for _, player := range p.Players {
+		if player.canAction() {
 			results = append(results, player)
 		}
 	}
@@ -304,37 +513,41 @@ func (p *Poker) GetFlopStrings() (results []string) {
 	return results
 }
 
-func (p *Poker) RandomAction() Action {
-	actions := []Action{
-		//Action{
-		//	Type: Fold,
-		//},
-		//Action{
-		//	Type: AllIn,
-		//},
-	}
-	cp := p.getCurrentPlayer()
-	if p.TurnBet-cp.CurrentBet > 0 && cp.Money >= p.TurnBet-cp.CurrentBet {
-		actions = append(actions, Action{
-			Type: Call,
-		})
+// GetBoardStrings は配った全てのボードのカードを、ボードごとに表示用の文字列で返す
+func (p *Poker) GetBoardStrings() (results [][]string) {
+	for _, board := range p.Boards() {
+		var cards []string
+		for _, card := range board {
+			cards = append(cards, fmt.Sprintf("%s : %s", card.Suit, strconv.Itoa(int(card.Number))))
+		}
+		results = append(results, cards)
 	}
-	if p.TurnBet-cp.CurrentBet <= 0 {
-		actions = append(actions, Action{
-			Type: Check,
-		})
+	return results
+}
+
+func (p *Poker) RandomAction() Action {
+	legal := p.LegalActions(p.getCurrentPlayer())
+	// Botはフォールドとオールインを選ばない
+	var actions []Action
+	for _, actionType := range legal.Types {
+		switch actionType {
+		case Call, Check:
+			actions = append(actions, Action{Type: actionType})
+		case Raise:
+			actions = append(actions, Action{Type: Raise, Bet: legal.MinRaiseTo})
+		}
 	}
-	if p.TurnBet-cp.CurrentBet > 0 && cp.Money >= (p.TurnBet*2-cp.CurrentBet) {
-		actions = append(actions, Action{
-			Type: Raise,
-			Bet:  (p.TurnBet*2 - cp.CurrentBet),
-		})
+	if len(actions) == 0 {
+		return Action{Type: Fold}
 	}
+	return actions[p.source().Rand().Intn(len(actions))]
+}
 
-	rand.Seed(time.Now().UnixNano())
-	rand.Shuffle(len(actions), func(i, j int) {
-		actions[i], actions[j] = actions[j], actions[i]
-	})
-
-	return actions[0]
+// source はデッキのシャッフルとBotの判断に使う乱数源を返す
+// 乱数源を1つにまとめることで、保存した状態から同じ続きを再現できる
+func (p *Poker) source() *deck.Source {
+	if p.Rand == nil {
+		p.Rand = deck.NewTimeSource()
+	}
+	return p.Rand
 }
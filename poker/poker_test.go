@@ -0,0 +1,48 @@
+package poker
+
+import (
+	"testing"
+)
+
+// newTestPoker は全員を人間が操作するゲームを作成する
+func newTestPoker(t *testing.T, bb, sb int, stacks ...int) *Poker {
+	t.Helper()
+	seats := DefaultSeats(len(stacks), stacks[0])
+	for i := range seats {
+		seats[i].Money = stacks[i]
+		seats[i].Controller = Human
+	}
+	p := NewPoker(bb, sb, seats)
+	if p == nil {
+		t.Fatal("NewPoker returned nil")
+	}
+	return p
+}
+
+// act は手番のプレイヤーにアクションさせ、次の手番へ進める
+func act(t *testing.T, p *Poker, a Action) {
+	t.Helper()
+	name := p.getCurrentPlayer().Name
+	if err := p.Action(a); err != nil {
+		t.Fatalf("%s %s: %v", name, a.Type, err)
+	}
+	p.NextPlayer()
+}
+
+// collectEvents はゲームのイベントを記録するスライスを返す
+func collectEvents(p *Poker) *[]Event {
+	events := &[]Event{}
+	p.AddListener(ListenerFunc(func(e Event) {
+		*events = append(*events, e)
+	}))
+	return events
+}
+
+// equalStacks は全員が同じ額のスタックを返す
+func equalStacks(count, stack int) []int {
+	stacks := make([]int, count)
+	for i := range stacks {
+		stacks[i] = stack
+	}
+	return stacks
+}
@@ -0,0 +1,376 @@
+package poker
+
+import (
+	"fmt"
+	"go_poker/card"
+	"go_poker/hand"
+	"io"
+	"strings"
+)
+
+// WritePokerStarsHand は1ハンドのイベントをPokerStars形式のハンド履歴として書き出す
+func WritePokerStarsHand(w io.Writer, events []Event, hero string) error {
+	if len(events) == 0 {
+		return ErrNoEvents
+	}
+	started, ok := events[0].(*HandStarted)
+	if !ok {
+		return ErrNotHandStarted
+	}
+	state := NewHandState(started)
+	potCount, runs, runFrom := 0, 1, StreetShowDown
+	for _, e := range events {
+		switch e := e.(type) {
+		case *PotAwarded:
+			if e.PotIndex >= potCount {
+				potCount = e.PotIndex + 1
+			}
+		case *StreetDealt:
+			if e.Board >= runs {
+				runs = e.Board + 1
+			}
+			if e.Board > 0 && e.Street < runFrom {
+				runFrom = e.Street
+			}
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "PokerStars Hand #%d: Hold'em %s ($%d/$%d) - %s\n",
+		started.HandID, pokerStarsLimit(started.Structure),
+		started.SmallBlind, started.BigBlind, started.Time.Format("2006/01/02 15:04:05 MST"))
+	fmt.Fprintf(&b, "Table 'go_poker' %d-max Seat #%d is the button\n", started.TableSize, started.Button+1)
+	for _, seat := range started.Seats {
+		fmt.Fprintf(&b, "Seat %d: %s ($%d in chips)\n", seat.Seat+1, seat.Name, seat.Stack)
+	}
+
+	foldStreets := map[int]Street{}
+	potAmounts := make([]int, potCount)
+	boardWins := map[int][]int{}
+	isHoleCardsWritten := false
+	isShowDown := false
+	showDownBoard := 0
+	writeShowDown := func() {
+		if isShowDown {
+			return
+		}
+		if runs > 1 {
+			fmt.Fprintf(&b, "*** %s SHOW DOWN ***\n", pokerStarsRunName(0))
+		} else {
+			b.WriteString("*** SHOW DOWN ***\n")
+		}
+		isShowDown = true
+	}
+	for _, e := range events[1:] {
+		switch e := e.(type) {
+		case *BlindPosted:
+			switch e.Type {
+			case SmallBlindPost:
+				fmt.Fprintf(&b, "%s: posts small blind $%d\n", e.Name, e.Amount)
+			case BigBlindPost:
+				fmt.Fprintf(&b, "%s: posts big blind $%d\n", e.Name, e.Amount)
+			case AntePost:
+				fmt.Fprintf(&b, "%s: posts the ante $%d\n", e.Name, e.Amount)
+			case StraddlePost:
+				fmt.Fprintf(&b, "%s: posts straddle $%d\n", e.Name, e.Amount)
+			}
+		case *HoleCardsDealt:
+			if !isHoleCardsWritten {
+				b.WriteString("*** HOLE CARDS ***\n")
+				isHoleCardsWritten = true
+			}
+			if hero == "" || e.Name == hero {
+				fmt.Fprintf(&b, "Dealt to %s [%s]\n", e.Name, formatCards(e.Cards))
+			}
+		case *ActionTaken:
+			seat, err := state.seat(e.Seat)
+			if err != nil {
+				return err
+			}
+			if e.Action.Type == Fold {
+				foldStreets[e.Seat] = state.Street
+			}
+			fmt.Fprintf(&b, "%s: %s\n", e.Name, pokerStarsAction(e, seat, state.maxBet()))
+		case *StreetDealt:
+			if e.Board == 0 {
+				prefix := ""
+				if runs > 1 && e.Street >= runFrom {
+					prefix = pokerStarsRunName(0) + " "
+				}
+				writePokerStarsStreet(&b, prefix, state.Board, e.Cards)
+				break
+			}
+			// 2つ目以降のボードはストリートごとに分けて書き出す
+			board := state.Board[:boardCardsBefore(e.Street)]
+			if e.Board < len(state.Boards) && len(state.Boards[e.Board]) > 0 {
+				board = state.Boards[e.Board]
+			}
+			board = append([]card.Card(nil), board...)
+			for cards := e.Cards; len(cards) > 0; {
+				count := 1
+				if len(board) == 0 {
+					count = 3
+				}
+				if count > len(cards) {
+					count = len(cards)
+				}
+				writePokerStarsStreet(&b, pokerStarsRunName(e.Board)+" ", board, cards[:count])
+				board = append(board, cards[:count]...)
+				cards = cards[count:]
+			}
+		case *ShowdownRevealed:
+			writeShowDown()
+			fmt.Fprintf(&b, "%s: shows [%s] (%s)\n", e.Name, formatCards(e.Cards), describeHand(e.Cards, state.Board))
+		case *HandMucked:
+			writeShowDown()
+			fmt.Fprintf(&b, "%s: mucks hand\n", e.Name)
+		case *CardsShown:
+			fmt.Fprintf(&b, "%s: shows [%s] (%s)\n", e.Name, formatCards(e.Cards), describeHand(e.Cards, state.Board))
+		case *BetReturned:
+			fmt.Fprintf(&b, "Uncalled bet ($%d) returned to %s\n", e.Amount, e.Name)
+		case *PotAwarded:
+			if e.Board > showDownBoard {
+				showDownBoard = e.Board
+				fmt.Fprintf(&b, "*** %s SHOW DOWN ***\n", pokerStarsRunName(e.Board))
+			}
+			if e.PotIndex < len(potAmounts) {
+				potAmounts[e.PotIndex] += e.Amount
+			}
+			for _, share := range e.Shares {
+				if boardWins[share.Seat] == nil {
+					boardWins[share.Seat] = make([]int, runs)
+				}
+				if e.Board < runs {
+					boardWins[share.Seat][e.Board] += share.Amount
+				}
+				fmt.Fprintf(&b, "%s collected $%d from %s\n", share.Name, share.Amount, pokerStarsPotName(e.PotIndex, potCount))
+			}
+		}
+		if err := state.Apply(e); err != nil {
+			return err
+		}
+	}
+
+	b.WriteString("*** SUMMARY ***\n")
+	total := 0
+	for _, seat := range state.Seats {
+		total += seat.TotalBet
+	}
+	fmt.Fprintf(&b, "Total pot $%d", total)
+	if potCount > 1 {
+		for i, amount := range potAmounts {
+			name := pokerStarsPotName(i, potCount)
+			fmt.Fprintf(&b, " %s%s $%d.", strings.ToUpper(name[:1]), name[1:], amount)
+		}
+	}
+	b.WriteString(" | Rake $0\n")
+	if runs > 1 {
+		times, ok := map[int]string{2: "twice", 3: "three times"}[runs]
+		if !ok {
+			times = fmt.Sprintf("%d times", runs)
+		}
+		fmt.Fprintf(&b, "Hand was run %s\n", times)
+		for i, board := range state.Boards {
+			fmt.Fprintf(&b, "%s Board [%s]\n", pokerStarsRunName(i), formatCards(board))
+		}
+	} else if len(state.Board) > 0 {
+		fmt.Fprintf(&b, "Board [%s]\n", formatCards(state.Board))
+	}
+	if len(state.Hunted) > 0 {
+		fmt.Fprintf(&b, "Hunted cards [%s]\n", formatCards(state.Hunted))
+	}
+	for _, seat := range state.Seats {
+		result := pokerStarsResult(seat, state.Board, foldStreets)
+		if runs > 1 && seat.IsRevealed {
+			result = pokerStarsRunResult(seat, state.Boards, boardWins[seat.Seat])
+		}
+		fmt.Fprintf(&b, "Seat %d: %s%s %s\n", seat.Seat+1, seat.Name, pokerStarsSeatRole(started, seat.Seat), result)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// maxBet はストリートで最も大きいベット額を返す
+func (s *HandState) maxBet() (result int) {
+	for _, seat := range s.Seats {
+		if seat.Bet > result {
+			result = seat.Bet
+		}
+	}
+	return result
+}
+
+func pokerStarsLimit(structure BettingStructure) string {
+	switch structure {
+	case PotLimit:
+		return "Pot Limit"
+	case FixedLimit:
+		return "Limit"
+	default:
+		return "No Limit"
+	}
+}
+
+// pokerStarsAction はアクション前の状態から、PokerStars形式のアクションの文言を返す
+func pokerStarsAction(e *ActionTaken, seat *SeatState, maxBet int) string {
+	paid := e.Amount - seat.Bet
+	var text string
+	switch {
+	case e.Action.Type == Fold:
+		return "folds"
+	case e.Action.Type == Check || paid == 0:
+		return "checks"
+	case e.Amount <= maxBet:
+		text = fmt.Sprintf("calls $%d", paid)
+	case maxBet == 0:
+		text = fmt.Sprintf("bets $%d", e.Amount)
+	default:
+		text = fmt.Sprintf("raises $%d to $%d", e.Amount-maxBet, e.Amount)
+	}
+	if seat.Stack == paid {
+		text += " and is all-in"
+	}
+	return text
+}
+
+// writePokerStarsStreet はボードに配ったカードを、それまでのボードと一緒にストリートの行として書き出す
+// prefixはボードを複数回配ったときの "FIRST " のようなボードの名前
+func writePokerStarsStreet(b *strings.Builder, prefix string, board, cards []card.Card) {
+	switch len(board) {
+	case 0:
+		fmt.Fprintf(b, "*** %sFLOP *** [%s]\n", prefix, formatCards(cards))
+	case 3:
+		fmt.Fprintf(b, "*** %sTURN *** [%s] [%s]\n", prefix, formatCards(board), formatCards(cards))
+	default:
+		fmt.Fprintf(b, "*** %sRIVER *** [%s] [%s]\n", prefix, formatCards(board), formatCards(cards))
+	}
+}
+
+// pokerStarsRunName はボードを複数回配ったときの、ボードの番号の英語の名前を返す
+func pokerStarsRunName(board int) string {
+	names := []string{"FIRST", "SECOND", "THIRD"}
+	if board < len(names) {
+		return names[board]
+	}
+	return fmt.Sprintf("#%d", board+1)
+}
+
+func pokerStarsPotName(index, count int) string {
+	if count <= 1 {
+		return "pot"
+	}
+	if index == 0 {
+		return "main pot"
+	}
+	return fmt.Sprintf("side pot-%d", index)
+}
+
+func pokerStarsSeatRole(started *HandStarted, seat int) string {
+	var roles []string
+	if seat == started.Button {
+		roles = append(roles, "button")
+	}
+	if seat == started.SmallBlindSeat {
+		roles = append(roles, "small blind")
+	}
+	if seat == started.BigBlindSeat {
+		roles = append(roles, "big blind")
+	}
+	if len(roles) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(roles, ") (") + ")"
+}
+
+// pokerStarsResult はサマリーに書き出すプレイヤーごとの結果を返す
+func pokerStarsResult(seat *SeatState, board []card.Card, foldStreets map[int]Street) string {
+	switch {
+	case seat.IsFolded:
+		street, ok := foldStreets[seat.Seat]
+		if !ok || street == StreetPreFlop {
+			if seat.TotalBet == 0 {
+				return "folded before Flop (didn't bet)"
+			}
+			return "folded before Flop"
+		}
+		return "folded on the " + street.String()
+	case seat.IsRevealed && seat.Won > 0:
+		return fmt.Sprintf("showed [%s] and won ($%d) with %s", formatCards(seat.HoleCards), seat.Won, describeHand(seat.HoleCards, board))
+	case seat.IsRevealed:
+		return fmt.Sprintf("showed [%s] and lost with %s", formatCards(seat.HoleCards), describeHand(seat.HoleCards, board))
+	case seat.Won > 0:
+		return fmt.Sprintf("collected ($%d)", seat.Won)
+	default:
+		return "mucked"
+	}
+}
+
+// pokerStarsRunResult はボードを複数回配ったときに、ショーダウンしたプレイヤーのボードごとの結果を返す
+func pokerStarsRunResult(seat *SeatState, boards [][]card.Card, wins []int) string {
+	results := make([]string, 0, len(boards))
+	for i, board := range boards {
+		if i < len(wins) && wins[i] > 0 {
+			results = append(results, fmt.Sprintf("won ($%d) with %s", wins[i], describeHand(seat.HoleCards, board)))
+		} else {
+			results = append(results, "lost with "+describeHand(seat.HoleCards, board))
+		}
+	}
+	return fmt.Sprintf("showed [%s] and %s", formatCards(seat.HoleCards), strings.Join(results, ", and "))
+}
+
+func formatCards(cards []card.Card) string {
+	texts := make([]string, 0, len(cards))
+	for _, c := range cards {
+		texts = append(texts, c.Short())
+	}
+	return strings.Join(texts, " ")
+}
+
+// describeHand はホールカードとボードの手役を "a pair of Kings" のような英語で返す
+func describeHand(holeCards, board []card.Card) string {
+	h := hand.Hand{Cards: holeCards}
+	h.Culc(board)
+	ranks := append(h.Ranks, 0, 0)
+	switch h.Point {
+	case hand.RoyalFlush:
+		return "a Royal Flush"
+	case hand.StraightFlush:
+		return fmt.Sprintf("a straight flush, %s to %s", rankName(ranks[0]-4), rankName(ranks[0]))
+	case hand.FourOfAKind:
+		return "four of a kind, " + rankPluralName(ranks[0])
+	case hand.AFullHouse:
+		return fmt.Sprintf("a full house, %s full of %s", rankPluralName(ranks[0]), rankPluralName(ranks[1]))
+	case hand.Flush:
+		return fmt.Sprintf("a flush, %s high", rankName(ranks[0]))
+	case hand.Straight:
+		return fmt.Sprintf("a straight, %s to %s", rankName(ranks[0]-4), rankName(ranks[0]))
+	case hand.ThreeOfAKind:
+		return "three of a kind, " + rankPluralName(ranks[0])
+	case hand.TwoPair:
+		return fmt.Sprintf("two pair, %s and %s", rankPluralName(ranks[0]), rankPluralName(ranks[1]))
+	case hand.OnePair:
+		return "a pair of " + rankPluralName(ranks[0])
+	default:
+		return "high card " + rankName(ranks[0])
+	}
+}
+
+// rankName はCardNumber.Rankの値を英語の名前で返す。1はホイールのAceとして扱う
+func rankName(rank int) string {
+	switch rank {
+	case 1, 14:
+		return "Ace"
+	case 2:
+		return "Deuce"
+	default:
+		return card.CardNumber(rank).ToString()
+	}
+}
+
+func rankPluralName(rank int) string {
+	if rank == 6 {
+		return "Sixes"
+	}
+	return rankName(rank) + "s"
+}
@@ -0,0 +1,455 @@
+package poker
+
+import (
+	"bufio"
+	"fmt"
+	"go_poker/card"
+	"go_poker/hand"
+	"go_poker/i18n"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseError はハンド履歴の読み込めなかった行。行のない形式ではLineが0になる
+type ParseError struct {
+	Line    int
+	Text    string
+	Message string
+}
+
+func (e *ParseError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%s: %s", e.Text, e.Message)
+	}
+	return i18n.T(msgParseErrorLine, e.Line, e.Message, e.Text)
+}
+
+// ParseErrors はハンド履歴の読み込みで見つかった全ての行のエラー
+type ParseErrors []*ParseError
+
+func (e ParseErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+var (
+	psHeaderPattern   = regexp.MustCompile(`^PokerStars (?:Hand|Game) #(\d+):`)
+	psBlindsPattern   = regexp.MustCompile(`\(([^()/]*\d[^()/]*)/([^()/]*\d[^()/]*?)(?: [A-Z]{3})?\)`)
+	psTablePattern    = regexp.MustCompile(`^Table '(.*)' (\d+)-max Seat #(\d+) is the button`)
+	psSeatPattern     = regexp.MustCompile(`^Seat (\d+): (.+) \((\S+) in chips(?:, \S+ bounty)?\)( is sitting out)?$`)
+	psPostPattern     = regexp.MustCompile(`^(.+): posts (small blind|big blind|the ante|small & big blinds|straddle) (\S+)`)
+	psDealtPattern    = regexp.MustCompile(`^Dealt to (.+?) \[([^\]]+)\]`)
+	psActionPattern   = regexp.MustCompile(`^(.+?): (folds|checks|calls|bets|raises)(?: (\S+))?(?: to (\S+))?( and is all-in)?$`)
+	psStreetPattern   = regexp.MustCompile(`^\*\*\* (?:(FIRST|SECOND|THIRD) )?(FLOP|TURN|RIVER) \*\*\* (?:\[[^\]]+\] )*\[([^\]]+)\]$`)
+	psShowDownPattern = regexp.MustCompile(`^\*\*\* (?:(FIRST|SECOND|THIRD) )?SHOW DOWN \*\*\*$`)
+	psShowPattern     = regexp.MustCompile(`^(.+?): shows \[([^\]]+)\]`)
+	psMuckPattern     = regexp.MustCompile(`^(.+?): mucks hand$`)
+	psUncalledPattern = regexp.MustCompile(`^Uncalled bet \((\S+)\) returned to (.+)$`)
+	psCollectPattern  = regexp.MustCompile(`^(.+?) collected (\S+) from (pot|main pot|side pot(?:-(\d+))?)$`)
+	psHuntedPattern   = regexp.MustCompile(`^Hunted cards \[([^\]]+)\]$`)
+	// psIgnoredPattern はハンドの状態に関係しないため読み飛ばす行
+	psIgnoredPattern = regexp.MustCompile(`^(?:\*\*\* HOLE CARDS \*\*\*|.+: (?:doesn't show hand|mucks hand|is sitting out|sits out|is disconnected|is connected|has timed out.*|shows \[.*)|.+ (?:said, ".*"|joins the table at seat #\d+|leaves the table|will be allowed to play after the button|has returned|is disconnected|is connected|has timed out.*|cashed out the hand.*|finished the tournament.*|wins the tournament.*))$`)
+)
+
+// ReadPokerStarsHands はPokerStars形式のハンド履歴ファイルを読み込み、ハンドごとのイベントに変換する
+// 小数を含む額はセント単位の整数に変換する。解釈できない行があったハンドは読み飛ばし、
+// 全ての行のエラーをParseErrorsとして返す
+func ReadPokerStarsHands(r io.Reader) ([]*HandLog, error) {
+	var logs []*HandLog
+	var errs ParseErrors
+	var parser *pokerStarsParser
+
+	finish := func() {
+		if parser != nil && parser.started != nil && parser.err == nil {
+			logs = append(logs, parser.finish())
+		}
+		parser = nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\uFEFF"))
+		if psHeaderPattern.MatchString(line) {
+			finish()
+			parser = &pokerStarsParser{handNumber: len(logs) + 1}
+		}
+		// 開始行を読み込めなかったハンドの残りの行は読み飛ばす
+		if parser == nil || line == "" || (parser.started == nil && parser.err != nil) {
+			continue
+		}
+		if err := parser.parseLine(line); err != nil {
+			parseErr := &ParseError{Line: lineNumber, Text: line, Message: err.Error()}
+			errs = append(errs, parseErr)
+			if parser.err == nil {
+				parser.err = parseErr
+			}
+		}
+	}
+	finish()
+	if err := scanner.Err(); err != nil {
+		return logs, err
+	}
+	if len(errs) > 0 {
+		return logs, errs
+	}
+	return logs, nil
+}
+
+type pokerStarsParser struct {
+	started       *HandStarted
+	events        []Event
+	seats         map[string]int
+	board         []card.Card
+	bets          map[int]int
+	pots          map[int]*PotAwarded
+	handNumber    int
+	isShowDown    bool
+	showDownBoard int
+	afterHand     []Event
+	isSummary     bool
+	err           *ParseError
+	scale         int
+}
+
+func (ps *pokerStarsParser) add(e Event) {
+	header := e.Header()
+	header.Sequence = len(ps.events) + 1
+	header.Time = ps.started.Time
+	ps.events = append(ps.events, e)
+}
+
+func (ps *pokerStarsParser) parseLine(line string) error {
+	if ps.started == nil {
+		return ps.parseHeader(line)
+	}
+	if ps.isSummary {
+		// まとめの行のうち、ラビットハントしたカードだけを読み込む
+		if m := psHuntedPattern.FindStringSubmatch(line); m != nil {
+			cards, err := card.ParseCards(m[1])
+			if err != nil {
+				return err
+			}
+			ps.afterHand = append(ps.afterHand, &RabbitHunted{Cards: cards})
+		}
+		return nil
+	}
+	if m := psShowDownPattern.FindStringSubmatch(line); m != nil {
+		// ボードを複数回配ったハンドでは、ボードごとにポットを分配する
+		if board := map[string]int{"SECOND": 1, "THIRD": 2}[m[1]]; board > 0 {
+			ps.addPots()
+			ps.showDownBoard = board
+		}
+		ps.isShowDown = true
+		return nil
+	}
+	if line == "*** SUMMARY ***" {
+		ps.isSummary = true
+		ps.addPots()
+		return nil
+	}
+
+	if m := psTablePattern.FindStringSubmatch(line); m != nil {
+		ps.started.TableSize, _ = strconv.Atoi(m[2])
+		button, _ := strconv.Atoi(m[3])
+		ps.started.Button = button - 1
+		return nil
+	}
+	if m := psSeatPattern.FindStringSubmatch(line); m != nil {
+		if len(ps.events) > 1 {
+			return i18n.Errorf(msgSeatAfterBlinds)
+		}
+		if m[4] != "" {
+			return nil
+		}
+		seat, _ := strconv.Atoi(m[1])
+		stack, err := ps.amount(m[3])
+		if err != nil {
+			return err
+		}
+		ps.seats[m[2]] = seat - 1
+		ps.started.Seats = append(ps.started.Seats, HandSeat{Seat: seat - 1, Name: m[2], Stack: stack})
+		return nil
+	}
+	if m := psPostPattern.FindStringSubmatch(line); m != nil {
+		return ps.parsePost(m[1], m[2], m[3])
+	}
+	if m := psDealtPattern.FindStringSubmatch(line); m != nil {
+		seat, err := ps.seat(m[1])
+		if err != nil {
+			return err
+		}
+		cards, err := card.ParseCards(m[2])
+		if err != nil {
+			return err
+		}
+		ps.add(&HoleCardsDealt{Seat: seat, Name: m[1], Cards: cards})
+		return nil
+	}
+	if m := psActionPattern.FindStringSubmatch(line); m != nil {
+		return ps.parseAction(m[1], m[2], m[3], m[4], m[5] != "")
+	}
+	if m := psStreetPattern.FindStringSubmatch(line); m != nil {
+		cards, err := card.ParseCards(m[3])
+		if err != nil {
+			return err
+		}
+		street := map[string]Street{"FLOP": StreetFlop, "TURN": StreetTurn, "RIVER": StreetRiver}[m[2]]
+		// ボードを複数回配ったハンドでは、2つ目以降のボードを別のボードとして配る
+		if board := map[string]int{"SECOND": 1, "THIRD": 2}[m[1]]; board > 0 {
+			ps.add(&StreetDealt{Board: board, Street: street, Cards: cards})
+			return nil
+		}
+		ps.board = append(ps.board, cards...)
+		ps.bets = map[int]int{}
+		ps.add(&StreetDealt{Street: street, Cards: cards})
+		return nil
+	}
+	// 全員がフォールドしてポットを獲得したあとに見せたカードは、ハンドの終了後に見せたものとして扱う
+	// それ以外にショーダウンより前に自分から見せたカードはハンドの状態に含めない
+	if m := psShowPattern.FindStringSubmatch(line); m != nil && (ps.isShowDown || len(ps.pots) > 0) {
+		seat, err := ps.seat(m[1])
+		if err != nil {
+			return err
+		}
+		cards, err := card.ParseCards(m[2])
+		if err != nil {
+			return err
+		}
+		h := hand.Hand{Cards: cards}
+		h.Culc(ps.board)
+		if !ps.isShowDown {
+			ps.afterHand = append(ps.afterHand, &CardsShown{Seat: seat, Name: m[1], Cards: cards, Point: h.Point})
+			return nil
+		}
+		ps.add(&ShowdownRevealed{Seat: seat, Name: m[1], Cards: cards, Point: h.Point})
+		return nil
+	}
+	if m := psMuckPattern.FindStringSubmatch(line); m != nil && ps.isShowDown {
+		seat, err := ps.seat(m[1])
+		if err != nil {
+			return err
+		}
+		ps.add(&HandMucked{Seat: seat, Name: m[1]})
+		return nil
+	}
+	if m := psUncalledPattern.FindStringSubmatch(line); m != nil {
+		seat, err := ps.seat(m[2])
+		if err != nil {
+			return err
+		}
+		amount, err := ps.amount(m[1])
+		if err != nil {
+			return err
+		}
+		ps.bets[seat] -= amount
+		ps.add(&BetReturned{Seat: seat, Name: m[2], Amount: amount})
+		return nil
+	}
+	if m := psCollectPattern.FindStringSubmatch(line); m != nil {
+		seat, err := ps.seat(m[1])
+		if err != nil {
+			return err
+		}
+		amount, err := ps.amount(m[2])
+		if err != nil {
+			return err
+		}
+		index := 0
+		if m[4] != "" {
+			index, _ = strconv.Atoi(m[4])
+		}
+		pot, ok := ps.pots[index]
+		if !ok {
+			pot = &PotAwarded{Board: ps.showDownBoard, PotIndex: index}
+			ps.pots[index] = pot
+		}
+		pot.Amount += amount
+		pot.Shares = append(pot.Shares, PotShare{Seat: seat, Name: m[1], Amount: amount})
+		return nil
+	}
+	if psIgnoredPattern.MatchString(line) {
+		return nil
+	}
+	return i18n.Errorf(msgUnknownLine)
+}
+
+func (ps *pokerStarsParser) parseHeader(line string) error {
+	m := psHeaderPattern.FindStringSubmatch(line)
+	if m == nil {
+		return i18n.Errorf(msgNotHandHeader)
+	}
+	if !strings.Contains(line, "Hold'em") {
+		return i18n.Errorf(msgNotHoldem)
+	}
+	id, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil {
+		return i18n.Errorf(msgInvalidHandNumber)
+	}
+	blinds := psBlindsPattern.FindAllStringSubmatch(line, -1)
+	if blinds == nil {
+		return i18n.Errorf(msgNoBlinds)
+	}
+	smallText, bigText := blinds[len(blinds)-1][1], blinds[len(blinds)-1][2]
+	ps.scale = 1
+	if strings.Contains(smallText+bigText, ".") {
+		ps.scale = 100
+	}
+	ps.started = &HandStarted{
+		HandNumber:     ps.handNumber,
+		HandID:         id,
+		Structure:      NoLimit,
+		SmallBlindSeat: -1,
+		BigBlindSeat:   -1,
+	}
+	if strings.Contains(line, "Pot Limit") {
+		ps.started.Structure = PotLimit
+	} else if !strings.Contains(line, "No Limit") && strings.Contains(line, "Limit") {
+		ps.started.Structure = FixedLimit
+	}
+	if ps.started.SmallBlind, err = ps.amount(smallText); err != nil {
+		return err
+	}
+	if ps.started.BigBlind, err = ps.amount(bigText); err != nil {
+		return err
+	}
+	ps.started.SmallBet = ps.started.BigBlind
+	ps.started.BigBet = ps.started.BigBlind * 2
+	if i := strings.LastIndex(line, " - "); i >= 0 {
+		if fields := strings.Fields(strings.TrimLeft(line[i+3:], "[")); len(fields) >= 2 {
+			ps.started.Time, _ = time.Parse("2006/01/02 15:04:05", fields[0]+" "+fields[1])
+		}
+	}
+	ps.seats = map[string]int{}
+	ps.bets = map[int]int{}
+	ps.pots = map[int]*PotAwarded{}
+	ps.add(ps.started)
+	return nil
+}
+
+func (ps *pokerStarsParser) parsePost(name, kind, amountText string) error {
+	seat, err := ps.seat(name)
+	if err != nil {
+		return err
+	}
+	amount, err := ps.amount(amountText)
+	if err != nil {
+		return err
+	}
+	switch kind {
+	case "small blind":
+		ps.started.SmallBlindSeat = seat
+		ps.add(&BlindPosted{Seat: seat, Name: name, Type: SmallBlindPost, Amount: amount})
+	case "big blind":
+		ps.started.BigBlindSeat = seat
+		ps.add(&BlindPosted{Seat: seat, Name: name, Type: BigBlindPost, Amount: amount})
+	case "the ante":
+		ps.started.Ante = amount
+		ps.add(&BlindPosted{Seat: seat, Name: name, Type: AntePost, Amount: amount})
+		return nil
+	case "straddle":
+		ps.add(&BlindPosted{Seat: seat, Name: name, Type: StraddlePost, Amount: amount})
+	case "small & big blinds":
+		// 途中から参加したプレイヤーのSB分はデッドマネーになる
+		dead := amount - ps.started.BigBlind
+		if dead > 0 {
+			ps.add(&BlindPosted{Seat: seat, Name: name, Type: AntePost, Amount: dead})
+		}
+		amount -= dead
+		ps.add(&BlindPosted{Seat: seat, Name: name, Type: BigBlindPost, Amount: amount})
+	}
+	ps.bets[seat] += amount
+	return nil
+}
+
+func (ps *pokerStarsParser) parseAction(name, verb, amountText, toText string, isAllIn bool) error {
+	seat, err := ps.seat(name)
+	if err != nil {
+		return err
+	}
+	bet := ps.bets[seat]
+	action := Action{}
+	switch verb {
+	case "folds":
+		action.Type = Fold
+	case "checks":
+		action.Type = Check
+	case "calls", "bets":
+		amount, err := ps.amount(amountText)
+		if err != nil {
+			return err
+		}
+		bet += amount
+		action.Type = Call
+		if verb == "bets" {
+			action = Action{Type: Raise, Bet: bet}
+		}
+	case "raises":
+		if toText == "" {
+			return i18n.Errorf(msgNoRaiseTo)
+		}
+		if bet, err = ps.amount(toText); err != nil {
+			return err
+		}
+		action = Action{Type: Raise, Bet: bet}
+	}
+	if isAllIn {
+		action = Action{Type: AllIn, Bet: bet}
+	}
+	ps.bets[seat] = bet
+	ps.add(&ActionTaken{Seat: seat, Name: name, Action: action, Amount: bet})
+	return nil
+}
+
+// addPots はポットごとに集計した獲得額を、メインポットから順にイベントにする
+func (ps *pokerStarsParser) addPots() {
+	indexes := make([]int, 0, len(ps.pots))
+	for index := range ps.pots {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	for _, index := range indexes {
+		ps.add(ps.pots[index])
+	}
+	ps.pots = map[int]*PotAwarded{}
+}
+
+func (ps *pokerStarsParser) finish() *HandLog {
+	if !ps.isSummary {
+		ps.addPots()
+	}
+	assignPositions(ps.started)
+	ps.add(&HandEnded{HandNumber: ps.started.HandNumber})
+	for _, e := range ps.afterHand {
+		ps.add(e)
+	}
+	return &HandLog{HandNumber: ps.started.HandNumber, Events: ps.events, IsComplete: true}
+}
+
+func (ps *pokerStarsParser) seat(name string) (int, error) {
+	seat, ok := ps.seats[name]
+	if !ok {
+		return 0, i18n.Errorf(msgUnknownPlayer, name)
+	}
+	return seat, nil
+}
+
+// amount は "$1.50" や "1,500" のような額を読み込み、ハンドの単位の整数にする
+func (ps *pokerStarsParser) amount(text string) (int, error) {
+	text = strings.TrimLeft(text, "$€£")
+	text = strings.ReplaceAll(text, ",", "")
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || value < 0 {
+		return 0, i18n.Errorf(msgInvalidAmount, text)
+	}
+	return int(math.Round(value * float64(ps.scale))), nil
+}
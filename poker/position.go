@@ -3,31 +3,68 @@ package poker
 type Position int
 
 const (
-	Dealer Position = iota + 1
+	BTN Position = iota + 1
 	SB
 	BB
+	UTG
+	UTG1
+	UTG2
+	MP
+	MP1
+	HJ
+	CO
 )
 
 func (p Position) String() string {
 	switch p {
-	case Dealer:
-		return "Dealer"
+	case BTN:
+		return "BTN"
 	case SB:
 		return "SB"
 	case BB:
 		return "BB"
+	case UTG:
+		return "UTG"
+	case UTG1:
+		return "UTG+1"
+	case UTG2:
+		return "UTG+2"
+	case MP:
+		return "MP"
+	case MP1:
+		return "MP+1"
+	case HJ:
+		return "HJ"
+	case CO:
+		return "CO"
 	default:
 		return "Normal"
 	}
 }
 
-func (p Position) Next() Position {
-	switch p {
-	case SB:
-		return BB
-	case BB:
-		return SB
+// PositionsFor はテーブル人数に応じたポジションをボタンから時計回りの順で返す
+// ヘッズアップではボタンがSBを兼ねる
+func PositionsFor(playerCount int) []Position {
+	switch playerCount {
+	case 2:
+		return []Position{BTN, BB}
+	case 3:
+		return []Position{BTN, SB, BB}
+	case 4:
+		return []Position{BTN, SB, BB, UTG}
+	case 5:
+		return []Position{BTN, SB, BB, UTG, CO}
+	case 6:
+		return []Position{BTN, SB, BB, UTG, HJ, CO}
+	case 7:
+		return []Position{BTN, SB, BB, UTG, MP, HJ, CO}
+	case 8:
+		return []Position{BTN, SB, BB, UTG, UTG1, MP, HJ, CO}
+	case 9:
+		return []Position{BTN, SB, BB, UTG, UTG1, UTG2, MP, HJ, CO}
+	case 10:
+		return []Position{BTN, SB, BB, UTG, UTG1, UTG2, MP, MP1, HJ, CO}
 	default:
-		return SB
+		return nil
 	}
 }
@@ -0,0 +1,109 @@
+package poker
+
+import (
+	"go_poker/i18n"
+	"go_poker/util"
+	"sort"
+)
+
+// Pot はメインポットまたはサイドポットと、それを獲得できるプレイヤー
+type Pot struct {
+	Amount    int
+	Eligibles []*Player
+}
+
+// BuildPots は各プレイヤーのハンド全体でのベット額から、メインポットとサイドポットを順に作成する
+// オールインしたプレイヤーは、自分のベット額に見合うポットにしか参加できない
+// アンテはデッドマネーとしてメインポットに入り、ポットの段階やベットの戻しには数えない
+func BuildPots(players []*Player) []Pot {
+	levels := make([]int, 0, len(players))
+	antes := 0
+	for _, player := range players {
+		antes += player.AntePaid
+		if player.isInHand() && player.liveBet() > 0 && !util.Contains(levels, player.liveBet()) {
+			levels = append(levels, player.liveBet())
+		}
+	}
+	sort.Ints(levels)
+	// アンテだけでオールインしたプレイヤーは、アンテだけのメインポットに参加できる
+	for _, player := range players {
+		if antes > 0 && player.isInHand() && player.liveBet() == 0 && (player.Money == 0 || len(levels) == 0) {
+			levels = append([]int{0}, levels...)
+			break
+		}
+	}
+
+	pots := make([]Pot, 0, len(levels))
+	prevLevel := 0
+	for i, level := range levels {
+		pot := Pot{}
+		if i == 0 {
+			pot.Amount = antes
+		}
+		for _, player := range players {
+			contribution := player.liveBet()
+			// 最後のポットにはフォールドしたプレイヤーの超過分も含める
+			if contribution > level && i < len(levels)-1 {
+				contribution = level
+			}
+			if contribution > prevLevel {
+				pot.Amount += contribution - prevLevel
+			}
+			if player.isInHand() && player.liveBet() >= level {
+				pot.Eligibles = append(pot.Eligibles, player)
+			}
+		}
+		pots = append(pots, pot)
+		prevLevel = level
+	}
+	return pots
+}
+
+func potName(index int) string {
+	if index == 0 {
+		return i18n.T(msgMainPot)
+	}
+	return i18n.T(msgSidePot, index)
+}
+
+// uncalledBet は座席ごとのアンテを除いたベット額から、誰にもコールされなかったベットの座席と額を返す
+// 最も多くベットした座席の、2番目に多いベット額を超える分がコールされなかった額になる
+// アンテはデッドマネーなので、コールされなかった額には含めない。なければ座席に-1を返す
+func uncalledBet(bets []int) (int, int) {
+	top, second := -1, 0
+	for i, bet := range bets {
+		switch {
+		case top < 0 || bet > bets[top]:
+			if top >= 0 {
+				second = bets[top]
+			}
+			top = i
+		case bet > second:
+			second = bet
+		}
+	}
+	if top < 0 || bets[top] <= second {
+		return -1, 0
+	}
+	return top, bets[top] - second
+}
+
+// returnUncalledBet は誰にもコールされなかったベットを、ベットしたプレイヤーに戻す
+func (p *Poker) returnUncalledBet() {
+	bets := make([]int, len(p.Players))
+	for i, player := range p.Players {
+		bets[i] = player.liveBet()
+	}
+	index, amount := uncalledBet(bets)
+	if index < 0 || !p.Players[index].isInHand() {
+		return
+	}
+	top := p.Players[index]
+	top.Money += amount
+	top.TotalBet -= amount
+	top.CurrentBet -= amount
+	if top.CurrentBet < 0 {
+		top.CurrentBet = 0
+	}
+	p.emit(&BetReturned{Seat: p.seatIndex(top), Name: top.Name, Amount: amount})
+}
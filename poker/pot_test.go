@@ -0,0 +1,207 @@
+package poker
+
+import (
+	"testing"
+)
+
+func TestBuildPots(t *testing.T) {
+	folded := Action{Type: Fold}
+	tests := []struct {
+		name      string
+		players   []*Player
+		amounts   []int
+		eligibles [][]string
+	}{
+		{
+			name: "全員が同じ額",
+			players: []*Player{
+				{Name: "A", TotalBet: 300},
+				{Name: "B", TotalBet: 300},
+				{Name: "C", TotalBet: 300},
+			},
+			amounts:   []int{900},
+			eligibles: [][]string{{"A", "B", "C"}},
+		},
+		{
+			name: "オールインでサイドポットができる",
+			players: []*Player{
+				{Name: "A", TotalBet: 500},
+				{Name: "B", TotalBet: 2000},
+				{Name: "C", TotalBet: 1200, CurrentAction: folded},
+				{Name: "D", TotalBet: 2000},
+			},
+			amounts:   []int{2000, 3700},
+			eligibles: [][]string{{"A", "B", "D"}, {"B", "D"}},
+		},
+		{
+			name: "フォールドしたプレイヤーの拠出はポットに入るが獲得できない",
+			players: []*Player{
+				{Name: "A", TotalBet: 100, CurrentAction: folded},
+				{Name: "B", TotalBet: 400},
+				{Name: "C", TotalBet: 400},
+			},
+			amounts:   []int{900},
+			eligibles: [][]string{{"B", "C"}},
+		},
+		{
+			name: "BBアンテはメインポットに入り、サイドポットを作らない",
+			players: []*Player{
+				{Name: "A", TotalBet: 200},
+				{Name: "B", TotalBet: 200},
+				{Name: "C", TotalBet: 400, AntePaid: 200},
+			},
+			amounts:   []int{800},
+			eligibles: [][]string{{"A", "B", "C"}},
+		},
+		{
+			name: "アンテだけでオールインしたプレイヤーはアンテのポットだけに参加できる",
+			players: []*Player{
+				{Name: "A", TotalBet: 25, AntePaid: 25},
+				{Name: "B", TotalBet: 425, AntePaid: 25, Money: 1000},
+				{Name: "C", TotalBet: 425, AntePaid: 25, Money: 1000},
+			},
+			amounts:   []int{75, 800},
+			eligibles: [][]string{{"A", "B", "C"}, {"B", "C"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pots := BuildPots(tt.players)
+			if len(pots) != len(tt.amounts) {
+				t.Fatalf("pots = %d, want %d", len(pots), len(tt.amounts))
+			}
+			for i, pot := range pots {
+				if pot.Amount != tt.amounts[i] {
+					t.Errorf("pot %d amount = %d, want %d", i, pot.Amount, tt.amounts[i])
+				}
+				var names []string
+				for _, player := range pot.Eligibles {
+					names = append(names, player.Name)
+				}
+				if len(names) != len(tt.eligibles[i]) {
+					t.Fatalf("pot %d eligibles = %v, want %v", i, names, tt.eligibles[i])
+				}
+				for j := range names {
+					if names[j] != tt.eligibles[i][j] {
+						t.Errorf("pot %d eligibles = %v, want %v", i, names, tt.eligibles[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+// findBetReturned はイベントの中から戻されたベットを探す
+func findBetReturned(events []Event) *BetReturned {
+	for _, e := range events {
+		if returned, ok := e.(*BetReturned); ok {
+			return returned
+		}
+	}
+	return nil
+}
+
+func TestReturnUncalledBetOnFold(t *testing.T) {
+	p := newTestPoker(t, 100, 50, 3000, 3000, 3000)
+	events := collectEvents(p)
+	p.StartHand()
+	raiser := p.getCurrentPlayer()
+	act(t, p, Action{Type: Raise, Bet: 1000})
+	act(t, p, Action{Type: Fold})
+	act(t, p, Action{Type: Fold})
+
+	returned := findBetReturned(*events)
+	if returned == nil {
+		t.Fatal("BetReturned was not emitted")
+	}
+	if returned.Name != raiser.Name || returned.Amount != 900 {
+		t.Errorf("BetReturned = %s %d, want %s 900", returned.Name, returned.Amount, raiser.Name)
+	}
+	var awarded *PotAwarded
+	for _, e := range *events {
+		if a, ok := e.(*PotAwarded); ok {
+			awarded = a
+		}
+	}
+	if awarded == nil || awarded.Amount != 250 || len(awarded.Shares) != 1 || awarded.Shares[0].Amount != 250 {
+		t.Fatalf("PotAwarded = %+v, want 250 to %s", awarded, raiser.Name)
+	}
+	if raiser.Money != 3150 {
+		t.Errorf("raiser money = %d, want 3150", raiser.Money)
+	}
+	state, err := p.CurrentHandLog().State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, seat := range state.Seats {
+		if seat.Stack != p.Players[seat.Seat].Money {
+			t.Errorf("%s stack = %d, want %d", seat.Name, seat.Stack, p.Players[seat.Seat].Money)
+		}
+		if seat.Name == raiser.Name && seat.Won != 250 {
+			t.Errorf("raiser won = %d, want 250", seat.Won)
+		}
+	}
+}
+
+func TestReturnUncalledBetOnAllIn(t *testing.T) {
+	p := newTestPoker(t, 100, 50, 5000, 1000)
+	events := collectEvents(p)
+	p.StartHand()
+	first := p.getCurrentPlayer()
+	act(t, p, Action{Type: AllIn})
+	act(t, p, Action{Type: Call})
+
+	returned := findBetReturned(*events)
+	if returned == nil {
+		t.Fatal("BetReturned was not emitted")
+	}
+	if returned.Name != first.Name || returned.Amount != 4000 {
+		t.Errorf("BetReturned = %s %d, want %s 4000", returned.Name, returned.Amount, first.Name)
+	}
+	total := 0
+	for _, e := range *events {
+		if a, ok := e.(*PotAwarded); ok {
+			total += a.Amount
+		}
+	}
+	if total != 2000 {
+		t.Errorf("awarded = %d, want 2000", total)
+	}
+	if p.Players[0].Money+p.Players[1].Money != 6000 {
+		t.Errorf("chips = %d, want 6000", p.Players[0].Money+p.Players[1].Money)
+	}
+}
+
+func TestBigBlindAnteIsNotReturned(t *testing.T) {
+	p := newTestPoker(t, 200, 100, 3000, 3000, 3000)
+	p.Ante = 200
+	p.BigBlindAnte = true
+	events := collectEvents(p)
+	p.StartHand()
+	act(t, p, Action{Type: Call})
+	act(t, p, Action{Type: Call})
+	act(t, p, Action{Type: Check})
+
+	if p.Street != StreetFlop {
+		t.Fatalf("street = %s, want Flop", p.Street)
+	}
+	if returned := findBetReturned(*events); returned != nil {
+		t.Errorf("BetReturned = %s %d, want none", returned.Name, returned.Amount)
+	}
+	if pot := p.CulcPot(); pot != 800 {
+		t.Errorf("pot = %d, want 800", pot)
+	}
+	if bb := p.Players[p.BigBlindIndex]; bb.Money != 2600 {
+		t.Errorf("big blind money = %d, want 2600", bb.Money)
+	}
+	state, err := p.CurrentHandLog().State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seat, amount := state.uncalledBet(); seat != nil {
+		t.Errorf("replayed uncalled bet = %s %d, want none", seat.Name, amount)
+	}
+	if pot := state.Pot(); pot != 800 {
+		t.Errorf("replayed pot = %d, want 800", pot)
+	}
+}
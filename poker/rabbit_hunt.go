@@ -0,0 +1,18 @@
+package poker
+
+// CanRabbitHunt はラビットハントできるかを返す
+// フォールドでハンドが終わり、ボードが全て配られておらず、まだラビットハントしていなければできる
+func (p *Poker) CanRabbitHunt() bool {
+	return p.IsHandFinished && len(p.getNotFoldPlayers()) == 1 && len(p.Flop) < 5 && len(p.Hunted) == 0
+}
+
+// RabbitHunt はフォールドで終わったハンドで、次に配られるはずだったボードのカードをデッキの残りから公開する
+// ポットは分配済みのため、公開したカードはハンドの結果に影響しない
+func (p *Poker) RabbitHunt() error {
+	if !p.CanRabbitHunt() {
+		return ErrCannotRabbitHunt
+	}
+	p.Hunted = p.Deck.Deal(5 - len(p.Flop))
+	p.emit(&RabbitHunted{Cards: p.Hunted})
+	return nil
+}
@@ -0,0 +1,63 @@
+package poker
+
+// MinRaiseBet はレイズ後のベット額として指定できる最小値を返す
+// 最低レイズ額は、このラウンドで最後に行われたフルレイズの上げ幅になる
+// フィックスドリミットでは、現在のストリートのベット単位だけ上乗せした額になる
+func (p *Poker) MinRaiseBet() int {
+	if p.Structure == FixedLimit {
+		return p.TurnBet + p.betSize()
+	}
+	return p.TurnBet + p.LastRaise
+}
+
+// MaxRaiseBet はプレイヤーがレイズ後のベット額として指定できる最大値を返す
+func (p *Poker) MaxRaiseBet(player *Player) int {
+	result := player.CurrentBet + player.Money
+	switch p.Structure {
+	case PotLimit:
+		if limit := p.potLimitBet(player); limit < result {
+			result = limit
+		}
+	case FixedLimit:
+		if limit := p.TurnBet + p.betSize(); limit < result {
+			result = limit
+		}
+	}
+	return result
+}
+
+// canRaise はプレイヤーがレイズできるかを返す
+// 既にアクションしたプレイヤーは、その後にフルレイズ分以上ベット額が上がった場合のみレイズできる
+func (p *Poker) canRaise(player *Player) bool {
+	if p.isRaiseCapped() {
+		return false
+	}
+	if player.CurrentAction.Type == 0 {
+		return true
+	}
+	return p.TurnBet-player.ActedBet >= p.LastRaise
+}
+
+// validateRaise はレイズ後のベット額がベットのルールに沿っているかを確認する
+// 最低レイズ額に満たなくても、所持金すべてを賭けるオールインであれば認める
+func (p *Poker) validateRaise(player *Player, raiseBet int) error {
+	if p.isRaiseCapped() {
+		return ErrRaiseCapReached.With(p.RaiseCap)
+	}
+	if !p.canRaise(player) {
+		return ErrRaiseNotReopened
+	}
+	if raiseBet <= p.TurnBet {
+		return ErrRaiseNotAboveBet
+	}
+	if raiseBet > player.CurrentBet+player.Money {
+		return ErrRaiseOverStack.With(p.MaxRaiseBet(player))
+	}
+	if raiseBet > p.MaxRaiseBet(player) {
+		return ErrRaiseOverLimit.With(p.Structure, p.MaxRaiseBet(player))
+	}
+	if raiseBet < p.MinRaiseBet() && raiseBet < p.MaxRaiseBet(player) {
+		return ErrRaiseTooSmall.With(p.MinRaiseBet())
+	}
+	return nil
+}
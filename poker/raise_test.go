@@ -0,0 +1,100 @@
+package poker
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestShortAllInDoesNotReopenRaise(t *testing.T) {
+	p := newTestPoker(t, 100, 50, 3000, 400, 3000)
+	p.StartHand()
+	opener := p.getCurrentPlayer()
+	if opener != p.Players[0] {
+		t.Fatalf("first to act = %s, want %s", opener.Name, p.Players[0].Name)
+	}
+	act(t, p, Action{Type: Raise, Bet: 300})
+	// SBの400のオールインは100しか上げておらず、フルレイズに満たない
+	act(t, p, Action{Type: AllIn})
+
+	bigBlind := p.getCurrentPlayer()
+	legal := p.LegalActions(bigBlind)
+	if !legal.Can(Raise) {
+		t.Fatalf("%s cannot raise: %+v", bigBlind.Name, legal)
+	}
+	if legal.MinRaiseTo != 600 {
+		t.Errorf("MinRaiseTo = %d, want 600", legal.MinRaiseTo)
+	}
+	act(t, p, Action{Type: Call})
+
+	if p.getCurrentPlayer() != opener {
+		t.Fatalf("turn = %s, want %s", p.getCurrentPlayer().Name, opener.Name)
+	}
+	legal = p.LegalActions(opener)
+	if legal.Can(Raise) || legal.Can(AllIn) {
+		t.Errorf("short all-in reopened the action: %+v", legal)
+	}
+	if legal.CallAmount != 100 {
+		t.Errorf("CallAmount = %d, want 100", legal.CallAmount)
+	}
+	if err := p.Action(Action{Type: Raise, Bet: 1000}); !errors.Is(err, ErrRaiseNotReopened) {
+		t.Errorf("Raise error = %v, want %v", err, ErrRaiseNotReopened)
+	}
+}
+
+func TestPotLimitMaxRaise(t *testing.T) {
+	p := newTestPoker(t, 100, 50, 3000, 3000, 3000, 3000)
+	p.Structure = PotLimit
+	p.StartHand()
+	utg := p.getCurrentPlayer()
+	if utg.Position != UTG {
+		t.Fatalf("first to act = %s, want UTG", utg.Position)
+	}
+
+	// コールした後のポットは250になり、そのぶん上乗せした350までレイズできる
+	legal := p.LegalActions(utg)
+	if legal.MinRaiseTo != 200 || legal.MaxRaiseTo != 350 {
+		t.Errorf("raise range = %d-%d, want 200-350", legal.MinRaiseTo, legal.MaxRaiseTo)
+	}
+	if legal.Can(AllIn) {
+		t.Errorf("all-in over the pot limit is legal: %+v", legal)
+	}
+	if err := p.Action(Action{Type: Raise, Bet: 400}); !errors.Is(err, ErrRaiseOverLimit) {
+		t.Errorf("Raise 400 error = %v, want %v", err, ErrRaiseOverLimit)
+	}
+	act(t, p, Action{Type: Raise, Bet: 350})
+
+	// 次のプレイヤーはコール後のポット850を上乗せした1200までレイズできる
+	if got := p.MaxRaiseBet(p.getCurrentPlayer()); got != 1200 {
+		t.Errorf("MaxRaiseBet = %d, want 1200", got)
+	}
+}
+
+func TestFixedLimitRaiseCap(t *testing.T) {
+	p := newTestPoker(t, 100, 50, 3000, 3000, 3000, 3000)
+	p.Structure = FixedLimit
+	p.StartHand()
+
+	// BBを1ベット目として、4ベットでレイズできなくなる
+	for _, to := range []int{200, 300, 400} {
+		player := p.getCurrentPlayer()
+		legal := p.LegalActions(player)
+		if !legal.Can(Raise) || legal.MinRaiseTo != to || legal.MaxRaiseTo != to {
+			t.Fatalf("%s legal = %+v, want raise to %d", player.Name, legal, to)
+		}
+		act(t, p, Action{Type: Raise, Bet: to})
+	}
+
+	player := p.getCurrentPlayer()
+	legal := p.LegalActions(player)
+	if legal.Can(Raise) || legal.Can(AllIn) {
+		t.Errorf("raise is legal after the cap: %+v", legal)
+	}
+	if err := p.Action(Action{Type: Raise, Bet: 500}); !errors.Is(err, ErrRaiseCapReached) {
+		t.Errorf("Raise error = %v, want %v", err, ErrRaiseCapReached)
+	}
+	// 先にレイズしたプレイヤーに手番が戻っても、コールかフォールドしか選べない
+	act(t, p, Action{Type: Call})
+	if legal := p.LegalActions(p.getCurrentPlayer()); legal.Can(Raise) {
+		t.Errorf("raise is legal after the cap: %+v", legal)
+	}
+}
@@ -0,0 +1,137 @@
+package poker
+
+import (
+	"go_poker/i18n"
+)
+
+// Replay はハンド履歴のハンドを、アクションごとに進めたり戻したりしながら再生する
+// 手番の開始やホールカードの配布のように、それだけでは見せる意味のないイベントは直前のステップにまとめる
+type Replay struct {
+	Logs      []*HandLog
+	HandIndex int
+	StepIndex int
+	steps     []replayStep
+}
+
+// replayStep は再生の1ステップ
+// eventは表示するイベントの位置で、endはそのステップまでに適用するイベントの数
+type replayStep struct {
+	event int
+	end   int
+}
+
+// NewReplay はハンド履歴の最初のハンドの開始時点から再生を始める
+func NewReplay(logs []*HandLog) (*Replay, error) {
+	if len(logs) == 0 {
+		return nil, ErrNoHands
+	}
+	r := &Replay{Logs: logs}
+	if err := r.SeekHand(0); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// SeekHand は指定したハンドの開始時点に移動する
+func (r *Replay) SeekHand(index int) error {
+	if index < 0 || index >= len(r.Logs) {
+		return i18n.Errorf(msgHandOutOfRange, len(r.Logs))
+	}
+	events := r.Logs[index].Events
+	if len(events) == 0 {
+		return ErrNoEvents
+	}
+	if _, ok := events[0].(*HandStarted); !ok {
+		return ErrNotHandStarted
+	}
+	steps := []replayStep{{event: 0, end: 1}}
+	for i, e := range events[1:] {
+		if isReplayStep(e) {
+			steps = append(steps, replayStep{event: i + 1, end: i + 2})
+		} else {
+			steps[len(steps)-1].end = i + 2
+		}
+	}
+	r.HandIndex = index
+	r.StepIndex = 0
+	r.steps = steps
+	return nil
+}
+
+// isReplayStep はイベントを1つのステップとして見せるかを返す
+func isReplayStep(e Event) bool {
+	switch e.(type) {
+	case *TurnStarted, *HoleCardsDealt, *RunItOffered:
+		return false
+	default:
+		return true
+	}
+}
+
+// Forward は次のステップに進める。ハンドの最後ならfalseを返す
+func (r *Replay) Forward() bool {
+	if r.StepIndex >= len(r.steps)-1 {
+		return false
+	}
+	r.StepIndex++
+	return true
+}
+
+// Back は前のステップに戻す。ハンドの最初ならfalseを返す
+func (r *Replay) Back() bool {
+	if r.StepIndex == 0 {
+		return false
+	}
+	r.StepIndex--
+	return true
+}
+
+// NextHand は次のハンドの開始時点に移動する。最後のハンドならfalseを返す
+func (r *Replay) NextHand() bool {
+	if r.HandIndex >= len(r.Logs)-1 {
+		return false
+	}
+	return r.SeekHand(r.HandIndex+1) == nil
+}
+
+// PrevHand は前のハンドの開始時点に移動する。最初のハンドならfalseを返す
+func (r *Replay) PrevHand() bool {
+	if r.HandIndex == 0 {
+		return false
+	}
+	return r.SeekHand(r.HandIndex-1) == nil
+}
+
+// IsAtEnd は現在のハンドの最後のステップまで進んでいるかを返す
+func (r *Replay) IsAtEnd() bool {
+	return r.StepIndex == len(r.steps)-1
+}
+
+// StepCount は現在のハンドのステップの数を返す
+func (r *Replay) StepCount() int {
+	return len(r.steps)
+}
+
+// Log は再生中のハンドのログを返す
+func (r *Replay) Log() *HandLog {
+	return r.Logs[r.HandIndex]
+}
+
+// Event は現在のステップで表示するイベントを返す
+func (r *Replay) Event() Event {
+	return r.Log().Events[r.steps[r.StepIndex].event]
+}
+
+// StepEvents は最初から現在のステップまでに表示したイベントを返す
+func (r *Replay) StepEvents() []Event {
+	events := make([]Event, 0, r.StepIndex+1)
+	for _, step := range r.steps[:r.StepIndex+1] {
+		events = append(events, r.Log().Events[step.event])
+	}
+	return events
+}
+
+// State は現在のステップまでのイベントから作り直したハンドの状態を返す
+func (r *Replay) State() (*HandState, error) {
+	return RebuildHand(r.Log().Events[:r.steps[r.StepIndex].end])
+}
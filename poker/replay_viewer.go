@@ -0,0 +1,328 @@
+package poker
+
+import (
+	"fmt"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go_poker/card"
+	"go_poker/i18n"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// replaySpeeds は自動再生で1ステップ進める間隔
+var replaySpeeds = []time.Duration{
+	2 * time.Second,
+	time.Second,
+	500 * time.Millisecond,
+	250 * time.Millisecond,
+	100 * time.Millisecond,
+}
+
+// ReplayViewer は保存したハンド履歴をTUIで1ステップずつ再生する
+type ReplayViewer struct {
+	Replay     *Replay
+	App        *tview.Application
+	rootFlex   *tview.Flex
+	handText   *tview.TextView
+	potText    *tview.TextView
+	streetText *tview.TextView
+	boardTable *tview.Table
+	eventText  *tview.TextView
+	speedText  *tview.TextView
+	controls   *tview.List
+	seatTable  *tview.Table
+	ticker     *time.Ticker
+	speedIndex int
+	isPlaying  bool
+}
+
+// NewReplayViewer はハンド履歴を再生するReplayViewerを作成する
+func NewReplayViewer(r *Replay) *ReplayViewer {
+	return &ReplayViewer{
+		Replay:     r,
+		App:        tview.NewApplication(),
+		speedIndex: 1,
+	}
+}
+
+// Start は画面を作成し、終了するまでTUIを動かす
+func (v *ReplayViewer) Start() error {
+	v.DrawInit()
+	v.ticker = time.NewTicker(replaySpeeds[v.speedIndex])
+	defer v.ticker.Stop()
+	go func() {
+		for range v.ticker.C {
+			v.App.QueueUpdateDraw(v.tick)
+		}
+	}()
+	return v.App.SetRoot(v.rootFlex, true).Run()
+}
+
+func (v *ReplayViewer) DrawInit() {
+	v.handText = tview.NewTextView().SetTextAlign(tview.AlignCenter)
+	v.handText.SetTitle("Hand").SetBorder(true).SetTitleColor(tcell.ColorNavy)
+
+	v.potText = tview.NewTextView().SetTextAlign(tview.AlignCenter)
+	v.potText.SetTitle("Pot").SetBorder(true).SetTitleColor(tcell.ColorYellow)
+
+	v.streetText = tview.NewTextView().SetTextColor(tcell.ColorGreen).SetTextAlign(tview.AlignCenter)
+	v.boardTable = tview.NewTable().SetBorders(true)
+
+	v.eventText = tview.NewTextView().SetTextColor(tcell.ColorOrange)
+	v.eventText.SetTitle("Events").SetTitleColor(tcell.ColorRed).SetBorder(true)
+
+	v.speedText = tview.NewTextView().SetTextAlign(tview.AlignCenter)
+
+	v.controls = tview.NewList().
+		AddItem(i18n.T(msgReplayNext), i18n.T(msgReplayNextHelp), 'n', func() {
+			v.forward()
+		}).
+		AddItem(i18n.T(msgReplayBack), i18n.T(msgReplayBackHelp), 'b', func() {
+			v.back()
+		}).
+		AddItem(i18n.T(msgReplayPlayPause), i18n.T(msgReplayPlayPauseHelp), 'p', func() {
+			v.togglePlay()
+		}).
+		AddItem(i18n.T(msgReplayFaster), i18n.T(msgReplayFasterHelp), '+', func() {
+			v.changeSpeed(1)
+		}).
+		AddItem(i18n.T(msgReplaySlower), i18n.T(msgReplaySlowerHelp), '-', func() {
+			v.changeSpeed(-1)
+		}).
+		AddItem(i18n.T(msgReplayNextHand), i18n.T(msgReplayNextHandHelp), ']', func() {
+			v.Replay.NextHand()
+			v.Draw()
+		}).
+		AddItem(i18n.T(msgReplayPrevHand), i18n.T(msgReplayPrevHandHelp), '[', func() {
+			v.Replay.PrevHand()
+			v.Draw()
+		}).
+		AddItem(i18n.T(msgReplayQuit), i18n.T(msgReplayQuitHelp), 'q', func() {
+			v.App.Stop()
+		})
+
+	v.seatTable = tview.NewTable().SetBorders(true)
+	v.seatTable.SetTitle("Seats").SetBorder(true).SetTitleColor(tcell.ColorGreen)
+
+	controlFlex := tview.NewFlex().SetDirection(tview.FlexRow)
+	controlFlex.SetBorder(true).SetTitle(i18n.T(msgReplayTitle))
+
+	v.rootFlex = tview.NewFlex().
+		AddItem(controlFlex.
+			AddItem(v.controls, v.controls.GetItemCount()*2, 1, true).
+			AddItem(v.speedText, 2, 1, false).
+			AddItem(tview.NewBox(), 0, 1, false), 0, 1, true).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(v.handText, 3, 1, false).
+			AddItem(v.potText, 3, 1, false).
+			AddItem(v.streetText, 2, 1, false).
+			AddItem(v.boardTable, 7, 1, false).
+			AddItem(v.eventText, 0, 1, false), 0, 2, false).
+		AddItem(v.seatTable, 0, 2, false)
+
+	v.App.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyRight:
+			v.forward()
+		case event.Key() == tcell.KeyLeft:
+			v.back()
+		case event.Key() == tcell.KeyRune && event.Rune() == ' ':
+			v.togglePlay()
+		default:
+			return event
+		}
+		return nil
+	})
+	v.Draw()
+}
+
+// forward は次のステップに進める。ハンドの最後なら次のハンドの最初に移る
+func (v *ReplayViewer) forward() bool {
+	moved := v.Replay.Forward() || v.Replay.NextHand()
+	v.Draw()
+	return moved
+}
+
+func (v *ReplayViewer) back() {
+	v.Replay.Back()
+	v.Draw()
+}
+
+// tick は自動再生中なら1ステップ進め、最後のハンドの最後まで進んだら一時停止する
+func (v *ReplayViewer) tick() {
+	if !v.isPlaying {
+		return
+	}
+	if !v.forward() {
+		v.isPlaying = false
+		v.drawSpeed()
+	}
+}
+
+func (v *ReplayViewer) togglePlay() {
+	v.isPlaying = !v.isPlaying
+	v.drawSpeed()
+}
+
+// changeSpeed は自動再生の間隔を段階的に変更する。deltaが正なら速くする
+func (v *ReplayViewer) changeSpeed(delta int) {
+	v.speedIndex += delta
+	if v.speedIndex < 0 {
+		v.speedIndex = 0
+	}
+	if v.speedIndex >= len(replaySpeeds) {
+		v.speedIndex = len(replaySpeeds) - 1
+	}
+	if v.ticker != nil {
+		v.ticker.Reset(replaySpeeds[v.speedIndex])
+	}
+	v.drawSpeed()
+}
+
+func (v *ReplayViewer) drawSpeed() {
+	status := i18n.T(msgReplayPaused)
+	if v.isPlaying {
+		status = i18n.T(msgReplayPlaying)
+	}
+	v.speedText.SetText(status + "\n" + i18n.T(msgReplaySpeed, replaySpeeds[v.speedIndex].Seconds()))
+}
+
+// Draw は現在のステップのハンドの状態を描き直す
+func (v *ReplayViewer) Draw() {
+	r := v.Replay
+	state, err := r.State()
+	if err != nil {
+		v.eventText.SetText(err.Error())
+		return
+	}
+	started := r.Log().Events[0].(*HandStarted)
+
+	v.handText.SetTitle(i18n.T(msgReplayHandTitle, r.HandIndex+1, len(r.Logs)))
+	v.handText.SetText(i18n.T(msgReplayStep, started.HandID, BlindLevel{
+		SmallBlind: started.SmallBlind,
+		BigBlind:   started.BigBlind,
+		Ante:       started.Ante,
+	}, r.StepIndex+1, r.StepCount()))
+	v.potText.SetText("＄" + strconv.Itoa(state.Pot()))
+	v.streetText.SetText(state.Street.String())
+	v.drawBoards(state)
+
+	var lines []string
+	for _, e := range r.StepEvents() {
+		lines = append(lines, replayEventText(e))
+	}
+	v.eventText.SetText(strings.Join(lines, "\n"))
+	v.eventText.ScrollToEnd()
+
+	v.drawSeatTable(state)
+	v.drawSpeed()
+}
+
+// drawBoards はボードを1行ずつ表示する。ボードを複数回配った場合は全てのボードを並べる
+// ラビットハントしたカードは最初のボードの続きに並べる
+func (v *ReplayViewer) drawBoards(state *HandState) {
+	v.boardTable.Clear()
+	boards := state.Boards
+	if len(boards) == 0 {
+		boards = [][]card.Card{state.Board}
+	}
+	for row, board := range boards {
+		for col, cardStr := range replayCardStrings(board) {
+			v.boardTable.SetCell(row, col, tview.NewTableCell(cardStr).
+				SetTextColor(cardSuitColor(strings.Split(cardStr, " ")[0])).
+				SetAlign(tview.AlignCenter))
+		}
+	}
+	// ラビットハントしたカードは、ボードの続きに灰色で表示する
+	for i, cardStr := range replayCardStrings(state.Hunted) {
+		v.boardTable.SetCell(0, len(state.Board)+i, tview.NewTableCell(cardStr).
+			SetTextColor(tcell.ColorGray).
+			SetAlign(tview.AlignCenter))
+	}
+}
+
+func (v *ReplayViewer) drawSeatTable(state *HandState) {
+	v.seatTable.Clear()
+	headers := []string{"Seat", "Name", "Pos", "Hand", "Stack", "Bet", "Action", "Won"}
+	for i, header := range headers {
+		v.seatTable.SetCell(0, i, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetAlign(tview.AlignCenter).
+			SetSelectable(false))
+	}
+
+	actor := -1
+	switch e := v.Replay.Event().(type) {
+	case *ActionTaken:
+		actor = e.Seat
+	case *BlindPosted:
+		actor = e.Seat
+	case *ShowdownRevealed:
+		actor = e.Seat
+	case *HandMucked:
+		actor = e.Seat
+	case *CardsShown:
+		actor = e.Seat
+	}
+	for i, seat := range state.Seats {
+		actionText := ""
+		if seat.LastAction.Type != 0 {
+			actionText = seat.LastAction.Type.String()
+		}
+		handText := strings.Join(replayCardStrings(seat.HoleCards), " / ")
+		if seat.IsRevealed {
+			handText += " (" + seat.Point.String() + ")"
+		} else if seat.IsMucked {
+			handText += i18n.T(msgReplayMuck)
+		}
+		color := tcell.ColorWhite
+		if seat.IsFolded {
+			color = tcell.ColorGray
+		} else if seat.Seat == actor {
+			color = tcell.ColorOrange
+		}
+
+		cells := []string{
+			strconv.Itoa(seat.Seat + 1),
+			seat.Name,
+			seat.Position.String(),
+			handText,
+			"＄" + strconv.Itoa(seat.Stack),
+			"＄" + strconv.Itoa(seat.Bet),
+			actionText,
+			"＄" + strconv.Itoa(seat.Won),
+		}
+		for j, cellText := range cells {
+			v.seatTable.SetCell(i+1, j, tview.NewTableCell(cellText).
+				SetTextColor(color).
+				SetAlign(tview.AlignCenter))
+		}
+	}
+}
+
+func replayCardStrings(cards []card.Card) (results []string) {
+	for _, c := range cards {
+		results = append(results, fmt.Sprintf("%s : %s", c.Suit, strconv.Itoa(int(c.Number))))
+	}
+	return results
+}
+
+// replayEventText は再生するイベントの文言に、ベット額や配られたカードを添えて返す
+func replayEventText(e Event) string {
+	switch e := e.(type) {
+	case *ActionTaken:
+		if e.Action.Type == Fold || e.Action.Type == Check {
+			return e.String()
+		}
+		return fmt.Sprintf("%s (＄%d)", e.String(), e.Amount)
+	case *StreetDealt:
+		if e.Board > 0 {
+			return i18n.T(msgBoardRunDealt, e.Board+1, formatCards(e.Cards))
+		}
+		return fmt.Sprintf("%s [%s]", e.String(), formatCards(e.Cards))
+	default:
+		return e.String()
+	}
+}
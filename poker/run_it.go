@@ -0,0 +1,129 @@
+package poker
+
+import (
+	"go_poker/card"
+)
+
+// MaxRunouts は残りのボードを配れる回数の上限
+const MaxRunouts = 3
+
+// isAllInRunout はハンドに2人以上が残り、アクションできるプレイヤーが1人以下で、残りのボードを配るだけかを返す
+func (p *Poker) isAllInRunout() bool {
+	return len(p.getNotFoldPlayers()) >= 2 && len(p.getActionablePlayers()) <= 1
+}
+
+// maxRunItTimes は残りのボードを配れる回数の上限を返す
+func (p *Poker) maxRunItTimes() int {
+	if p.MaxRuns > MaxRunouts {
+		return MaxRunouts
+	}
+	if p.MaxRuns < 1 {
+		return 1
+	}
+	return p.MaxRuns
+}
+
+// offerRunIt は全員がオールインしたときに、ハンドに残った全員へ残りのボードを配る回数を尋ねる
+// 複数回配れない設定なら尋ねずに配る。BotはMaxRunsまでの回数をその場でランダムに選ぶ
+// 人間のプレイヤーはChooseRunItTimesで選び、全員が選ぶまでボードは配らない
+func (p *Poker) offerRunIt() {
+	maxRuns := p.maxRunItTimes()
+	if maxRuns <= 1 {
+		p.runOut(1)
+		return
+	}
+	p.IsChoosingRuns = true
+	for _, player := range p.getNotFoldPlayers() {
+		player.RunItTimes = 0
+		if player.IsBot() {
+			player.RunItTimes = p.source().Rand().Intn(maxRuns) + 1
+		}
+	}
+	p.emit(&RunItOffered{MaxRuns: maxRuns})
+	p.runOutIfChosen()
+}
+
+// ChooseRunItTimes はオールインのときに、残りのボードを配る回数をプレイヤーが選ぶ
+// ハンドに残った全員が選ぶと、選ばれた中で最も少ない回数だけ残りのボードを配る
+func (p *Poker) ChooseRunItTimes(player *Player, runs int) error {
+	if !p.IsChoosingRuns || !player.isInHand() || player.RunItTimes > 0 {
+		return ErrNotChoosingRuns
+	}
+	if runs < 1 || runs > p.maxRunItTimes() {
+		return ErrInvalidRunItTimes.With(p.maxRunItTimes())
+	}
+	player.RunItTimes = runs
+	p.runOutIfChosen()
+	return nil
+}
+
+// IsChoosingRunItTimes はプレイヤーが残りのボードを配る回数をまだ選んでいないかを返す
+func (p *Poker) IsChoosingRunItTimes(player *Player) bool {
+	return p.IsChoosingRuns && player.isInHand() && player.RunItTimes == 0
+}
+
+// runOutIfChosen はハンドに残った全員が回数を選んでいれば、最も少ない回数だけ残りのボードを配る
+func (p *Poker) runOutIfChosen() {
+	runs := p.maxRunItTimes()
+	for _, player := range p.getNotFoldPlayers() {
+		if player.RunItTimes == 0 {
+			return
+		}
+		if player.RunItTimes < runs {
+			runs = player.RunItTimes
+		}
+	}
+	p.IsChoosingRuns = false
+	p.runOut(runs)
+}
+
+// runOut は全員がオールインしたあと、残りのボードをruns回配ってショーダウンする
+// 複数回配る場合は、最初のボードをリバーまで配ってから、2つ目以降のボードの残りのカードをまとめて配る
+// 2つ目以降のボードは、オールインになるまでに配られていたカードを最初のボードと共有する
+func (p *Poker) runOut(runs int) {
+	if runs > 1 {
+		p.emit(&RunItAgreed{Runs: runs})
+	}
+	shared := len(p.Flop)
+	firstStreet := p.Street + 1
+	for p.Street < StreetRiver {
+		p.Street++
+		p.startBettingRound()
+		p.OpenFlop()
+	}
+	for board := 1; board < runs; board++ {
+		cards := p.Deck.Deal(len(p.Flop) - shared)
+		p.Runouts = append(p.Runouts, append(append([]card.Card(nil), p.Flop[:shared]...), cards...))
+		p.emit(&StreetDealt{Board: board, Street: firstStreet, Cards: cards})
+	}
+	p.Street = StreetShowDown
+	p.ShowDown()
+}
+
+// Boards は配った全てのボードを返す。最初のボードはFlopで、複数回配った場合は続けて2つ目以降のボードを返す
+func (p *Poker) Boards() [][]card.Card {
+	return append([][]card.Card{p.Flop}, p.Runouts...)
+}
+
+// splitRuns はポットをボードの数で等分した額をボードの順に返す
+// 等分はChipUnitの単位で行い、余ったチップは最初のボードから1単位ずつ配る
+func (p *Poker) splitRuns(amount, runs int) []int {
+	unit := p.ChipUnit
+	if unit <= 0 {
+		unit = 1
+	}
+	amounts := make([]int, runs)
+	share := amount / runs / unit * unit
+	for i := range amounts {
+		amounts[i] = share
+	}
+	for i, oddChips := 0, amount-share*runs; oddChips > 0; i = (i + 1) % runs {
+		chip := unit
+		if chip > oddChips {
+			chip = oddChips
+		}
+		amounts[i] += chip
+		oddChips -= chip
+	}
+	return amounts
+}
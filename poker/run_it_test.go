@@ -0,0 +1,137 @@
+package poker
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+// allInPreFlop は3人のうち最初のプレイヤーがオールインし、次がフォールド、最後がコールしたところまで進める
+func allInPreFlop(t *testing.T, p *Poker) (*Player, *Player) {
+	t.Helper()
+	p.StartHand()
+	first := p.getCurrentPlayer()
+	act(t, p, Action{Type: AllIn})
+	act(t, p, Action{Type: Fold})
+	last := p.getCurrentPlayer()
+	act(t, p, Action{Type: Call})
+	return first, last
+}
+
+func TestChooseRunItTimes(t *testing.T) {
+	p := newTestPoker(t, 100, 50, 1000, 1000, 1000)
+	p.MaxRuns = 3
+	events := collectEvents(p)
+	first, last := allInPreFlop(t, p)
+
+	if !p.IsChoosingRuns || p.IsHandFinished || len(p.Flop) != 0 {
+		t.Fatalf("choosing = %v, finished = %v, flop = %d", p.IsChoosingRuns, p.IsHandFinished, len(p.Flop))
+	}
+	// チップを持つプレイヤーが1人だけでも、ボードを配るまではゲームは終わらない
+	if p.IsGameOver() {
+		t.Error("IsGameOver while choosing how many times to run it")
+	}
+	if legal := p.LegalActions(p.getCurrentPlayer()); len(legal.Types) != 0 {
+		t.Errorf("LegalActions while choosing = %+v", legal)
+	}
+	if err := p.Action(Action{Type: Check}); !errors.Is(err, ErrChoosingRuns) {
+		t.Errorf("Action error = %v, want %v", err, ErrChoosingRuns)
+	}
+	for _, player := range p.Players {
+		if player != first && player != last {
+			if err := p.ChooseRunItTimes(player, 2); !errors.Is(err, ErrNotChoosingRuns) {
+				t.Errorf("folded player error = %v, want %v", err, ErrNotChoosingRuns)
+			}
+		}
+	}
+	if err := p.ChooseRunItTimes(first, 4); !errors.Is(err, ErrInvalidRunItTimes) {
+		t.Errorf("4 runs error = %v, want %v", err, ErrInvalidRunItTimes)
+	}
+
+	if err := p.ChooseRunItTimes(first, 3); err != nil {
+		t.Fatal(err)
+	}
+	if !p.IsChoosingRuns {
+		t.Fatal("the board was run before everyone chose")
+	}
+	if err := p.ChooseRunItTimes(first, 1); !errors.Is(err, ErrNotChoosingRuns) {
+		t.Errorf("second choice error = %v, want %v", err, ErrNotChoosingRuns)
+	}
+
+	// セーブして再開しても、選んだ回数と選んでいる途中であることは引き継がれる
+	path := filepath.Join(t.TempDir(), "game.json")
+	if err := p.SaveGame(path); err != nil {
+		t.Fatal(err)
+	}
+	q, err := LoadGame(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !q.IsChoosingRuns || !q.IsChoosingRunItTimes(q.Players[p.seatIndex(last)]) || q.IsChoosingRunItTimes(q.Players[p.seatIndex(first)]) {
+		t.Fatal("the run it choice was not resumed")
+	}
+
+	if err := p.ChooseRunItTimes(last, 2); err != nil {
+		t.Fatal(err)
+	}
+	if p.IsChoosingRuns || !p.IsHandFinished || len(p.Boards()) != 2 {
+		t.Fatalf("choosing = %v, finished = %v, boards = %d, want 2 boards", p.IsChoosingRuns, p.IsHandFinished, len(p.Boards()))
+	}
+	var offered *RunItOffered
+	var agreed *RunItAgreed
+	for _, e := range *events {
+		switch e := e.(type) {
+		case *RunItOffered:
+			offered = e
+		case *RunItAgreed:
+			agreed = e
+		}
+	}
+	if offered == nil || offered.MaxRuns != 3 {
+		t.Errorf("RunItOffered = %+v, want 3 runs at most", offered)
+	}
+	if agreed == nil || agreed.Runs != 2 {
+		t.Errorf("RunItAgreed = %+v, want 2 runs", agreed)
+	}
+}
+
+func TestRunItOnceWithoutChoice(t *testing.T) {
+	p := newTestPoker(t, 100, 50, 1000, 1000, 1000)
+	p.MaxRuns = 1
+	events := collectEvents(p)
+	allInPreFlop(t, p)
+
+	if p.IsChoosingRuns || !p.IsHandFinished || len(p.Boards()) != 1 {
+		t.Fatalf("choosing = %v, finished = %v, boards = %d", p.IsChoosingRuns, p.IsHandFinished, len(p.Boards()))
+	}
+	for _, e := range *events {
+		if _, ok := e.(*RunItOffered); ok {
+			t.Error("RunItOffered was emitted for a single run")
+		}
+	}
+}
+
+func TestBotChoosesRunItTimes(t *testing.T) {
+	p := newTestPoker(t, 100, 50, 1000, 1000, 1000)
+	p.MaxRuns = 3
+	bot := p.Players[2]
+	bot.Controller = Bot
+	p.StartHand()
+	first := p.getCurrentPlayer()
+	act(t, p, Action{Type: AllIn})
+	// フォールドすると、BBのBotがオールインにコールする
+	act(t, p, Action{Type: Fold})
+
+	if !p.IsChoosingRuns || p.IsChoosingRunItTimes(bot) || !p.IsChoosingRunItTimes(first) {
+		t.Fatalf("choosing = %v, bot runs = %d", p.IsChoosingRuns, bot.RunItTimes)
+	}
+	if bot.RunItTimes < 1 || bot.RunItTimes > 3 {
+		t.Fatalf("bot runs = %d, want 1 to 3", bot.RunItTimes)
+	}
+	if err := p.ChooseRunItTimes(first, 3); err != nil {
+		t.Fatal(err)
+	}
+	if !p.IsHandFinished || len(p.Boards()) != bot.RunItTimes {
+		t.Errorf("finished = %v, boards = %d, want %d", p.IsHandFinished, len(p.Boards()), bot.RunItTimes)
+	}
+}
@@ -0,0 +1,333 @@
+package poker
+
+import (
+	"encoding/json"
+	"fmt"
+	"go_poker/card"
+	"go_poker/deck"
+	"go_poker/i18n"
+	"os"
+	"strings"
+	"time"
+)
+
+// savedGameVersion は保存ファイルの形式のバージョン
+const savedGameVersion = 1
+
+// savedGame はゲームを中断したときの状態で、そのままJSONとして保存する
+// デッキの残りの順番と乱数源の状態も保存するため、再開後も中断しなかった場合と同じカードが配られる
+// 完了したハンドのログは保存せず、進行中のハンドのイベントだけを保存する
+type savedGame struct {
+	Version             int
+	SavedAt             time.Time
+	Players             []*Player
+	Deck                []card.Card
+	Rand                uint64
+	BigBlind            int
+	SmollBlind          int
+	Ante                int
+	BigBlindAnte        bool
+	Straddle            StraddleType
+	MaxReStraddles      int
+	StraddleIndexes     []int
+	Schedule            *BlindSchedule
+	Level               int
+	LevelElapsed        time.Duration
+	LevelStartedHand    int
+	Tournament          *savedTournament
+	CashGame            *CashGame
+	Flop                []card.Card
+	Runouts             [][]card.Card
+	Hunted              []card.Card
+	MaxRuns             int
+	Street              Street
+	Structure           BettingStructure
+	SmallBet            int
+	BigBet              int
+	RaiseCap            int
+	RaiseCount          int
+	OddChipRule         OddChipRule
+	ChipUnit            int
+	Button              int
+	SmallBlindIndex     int
+	BigBlindIndex       int
+	TurnIndex           int
+	TurnBet             int
+	LastRaise           int
+	RoundAggressorIndex int
+	LastAggressorIndex  int
+	IsHandFinished      bool
+	IsChoosingRuns      bool
+	HandCount           int
+	CurrentHand         []savedEvent
+}
+
+// savedTournament はトーナメントの進行状況で、プレイヤーは座席の番号で保存する
+type savedTournament struct {
+	BuyIn           int
+	StartingStack   int
+	Payouts         []int
+	Eliminations    []int
+	HandStartStacks []int
+}
+
+// savedEvent は進行中のハンドのイベントを、型の名前と一緒に保存する
+type savedEvent struct {
+	Type  string
+	Event json.RawMessage
+}
+
+// SaveGame はゲームの状態をファイルに保存する
+// リスナーは保存しないため、再開するときに登録し直す
+func (p *Poker) SaveGame(path string) error {
+	saved, err := p.savedGame(time.Now())
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func (p *Poker) savedGame(now time.Time) (*savedGame, error) {
+	saved := &savedGame{
+		Version:             savedGameVersion,
+		SavedAt:             now,
+		Players:             p.Players,
+		Deck:                p.Deck.Cards,
+		Rand:                p.source().State,
+		BigBlind:            p.BigBlind,
+		SmollBlind:          p.SmollBlind,
+		Ante:                p.Ante,
+		BigBlindAnte:        p.BigBlindAnte,
+		Straddle:            p.Straddle,
+		MaxReStraddles:      p.MaxReStraddles,
+		StraddleIndexes:     p.StraddleIndexes,
+		Schedule:            p.Schedule,
+		Level:               p.Level,
+		LevelStartedHand:    p.LevelStartedHand,
+		CashGame:            p.CashGame,
+		Flop:                p.Flop,
+		Runouts:             p.Runouts,
+		Hunted:              p.Hunted,
+		MaxRuns:             p.MaxRuns,
+		Street:              p.Street,
+		Structure:           p.Structure,
+		SmallBet:            p.SmallBet,
+		BigBet:              p.BigBet,
+		RaiseCap:            p.RaiseCap,
+		RaiseCount:          p.RaiseCount,
+		OddChipRule:         p.OddChipRule,
+		ChipUnit:            p.ChipUnit,
+		Button:              p.Button,
+		SmallBlindIndex:     p.SmallBlindIndex,
+		BigBlindIndex:       p.BigBlindIndex,
+		TurnIndex:           p.TurnIndex,
+		TurnBet:             p.TurnBet,
+		LastRaise:           p.LastRaise,
+		RoundAggressorIndex: p.RoundAggressorIndex,
+		LastAggressorIndex:  p.LastAggressorIndex,
+		IsHandFinished:      p.IsHandFinished,
+		IsChoosingRuns:      p.IsChoosingRuns,
+		HandCount:           p.HandCount,
+	}
+	// 中断していた間はブラインドレベルの時間を進めない
+	if p.Schedule != nil {
+		saved.LevelElapsed = now.Sub(p.LevelStartedAt)
+	}
+	if t := p.Tournament; t != nil {
+		saved.Tournament = &savedTournament{
+			BuyIn:         t.BuyIn,
+			StartingStack: t.StartingStack,
+			Payouts:       t.Payouts,
+		}
+		for _, player := range t.Eliminations {
+			saved.Tournament.Eliminations = append(saved.Tournament.Eliminations, p.seatIndex(player))
+		}
+		for _, player := range p.Players {
+			saved.Tournament.HandStartStacks = append(saved.Tournament.HandStartStacks, t.handStartStacks[player])
+		}
+	}
+	if log := p.CurrentHandLog(); log != nil && !log.IsComplete {
+		for _, e := range log.Events {
+			data, err := json.Marshal(e)
+			if err != nil {
+				return nil, err
+			}
+			saved.CurrentHand = append(saved.CurrentHand, savedEvent{
+				Type:  strings.TrimPrefix(fmt.Sprintf("%T", e), "*poker."),
+				Event: data,
+			})
+		}
+	}
+	return saved, nil
+}
+
+// LoadGame はSaveGameで保存したファイルからゲームを再開する
+// 進行中のハンドがあれば、そのハンドのイベントをハンドログに戻す
+func LoadGame(path string) (*Poker, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	// アグレッサーを持たない以前のファイルでは、ボタンの次のプレイヤーから手を見せる
+	saved := &savedGame{RoundAggressorIndex: -1, LastAggressorIndex: -1}
+	if err := json.Unmarshal(data, saved); err != nil {
+		return nil, ErrLoadSavedGame.Wrap(err)
+	}
+	return saved.poker(time.Now())
+}
+
+func (s *savedGame) poker(now time.Time) (*Poker, error) {
+	if s.Version != savedGameVersion {
+		return nil, ErrSavedGameVersion.With(s.Version)
+	}
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	p := &Poker{
+		Players:             s.Players,
+		Deck:                &deck.Deck{Cards: s.Deck},
+		Rand:                &deck.Source{State: s.Rand},
+		BigBlind:            s.BigBlind,
+		SmollBlind:          s.SmollBlind,
+		Ante:                s.Ante,
+		BigBlindAnte:        s.BigBlindAnte,
+		Straddle:            s.Straddle,
+		MaxReStraddles:      s.MaxReStraddles,
+		StraddleIndexes:     s.StraddleIndexes,
+		Schedule:            s.Schedule,
+		Level:               s.Level,
+		LevelStartedAt:      now.Add(-s.LevelElapsed),
+		LevelStartedHand:    s.LevelStartedHand,
+		CashGame:            s.CashGame,
+		Flop:                s.Flop,
+		Runouts:             s.Runouts,
+		Hunted:              s.Hunted,
+		MaxRuns:             s.MaxRuns,
+		Street:              s.Street,
+		Structure:           s.Structure,
+		SmallBet:            s.SmallBet,
+		BigBet:              s.BigBet,
+		RaiseCap:            s.RaiseCap,
+		RaiseCount:          s.RaiseCount,
+		OddChipRule:         s.OddChipRule,
+		ChipUnit:            s.ChipUnit,
+		Button:              s.Button,
+		SmallBlindIndex:     s.SmallBlindIndex,
+		BigBlindIndex:       s.BigBlindIndex,
+		TurnIndex:           s.TurnIndex,
+		TurnBet:             s.TurnBet,
+		LastRaise:           s.LastRaise,
+		RoundAggressorIndex: s.RoundAggressorIndex,
+		LastAggressorIndex:  s.LastAggressorIndex,
+		IsHandFinished:      s.IsHandFinished,
+		IsChoosingRuns:      s.IsChoosingRuns,
+		HandCount:           s.HandCount,
+	}
+	if st := s.Tournament; st != nil {
+		t := &Tournament{
+			BuyIn:           st.BuyIn,
+			StartingStack:   st.StartingStack,
+			Payouts:         st.Payouts,
+			handStartStacks: make(map[*Player]int, len(p.Players)),
+		}
+		for _, seat := range st.Eliminations {
+			if seat < 0 || seat >= len(p.Players) {
+				return nil, i18n.Errorf(msgSavedEliminatedSeat, seat+1)
+			}
+			t.Eliminations = append(t.Eliminations, p.Players[seat])
+		}
+		for i, stack := range st.HandStartStacks {
+			if i < len(p.Players) {
+				t.handStartStacks[p.Players[i]] = stack
+			}
+		}
+		p.Tournament = t
+	}
+	if len(s.CurrentHand) > 0 {
+		log := &HandLog{HandNumber: s.HandCount}
+		for _, saved := range s.CurrentHand {
+			e, err := newSavedEvent(saved.Type)
+			if err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(saved.Event, e); err != nil {
+				return nil, i18n.Errorf(msgLoadSavedEvent, saved.Type).Wrap(err)
+			}
+			log.Events = append(log.Events, e)
+		}
+		p.HandLogs = []*HandLog{log}
+	}
+	return p, nil
+}
+
+func (s *savedGame) validate() error {
+	if len(s.Players) < MinSeats || len(s.Players) > MaxSeats {
+		return i18n.Errorf(msgSavedPlayerCount, MinSeats, MaxSeats)
+	}
+	for _, index := range append([]int{s.Button, s.SmallBlindIndex, s.BigBlindIndex, s.TurnIndex}, s.StraddleIndexes...) {
+		if index < 0 || index >= len(s.Players) {
+			return i18n.Errorf(msgNoSeat, index+1)
+		}
+	}
+	if s.Schedule != nil {
+		if err := s.Schedule.Validate(); err != nil {
+			return err
+		}
+		if s.Level < 0 || s.Level >= len(s.Schedule.Levels) {
+			return i18n.Errorf(msgNoBlindLevel, s.Level+1)
+		}
+	}
+	if len(s.Deck)+len(s.Flop) > 52 {
+		return i18n.Errorf(msgTooManyCards)
+	}
+	return nil
+}
+
+// newSavedEvent は保存したイベントの型の名前から、読み込み先のイベントを作成する
+// 進行中のハンドで起きるイベントだけを扱う
+func newSavedEvent(name string) (Event, error) {
+	switch name {
+	case "HandStarted":
+		return &HandStarted{}, nil
+	case "BlindPosted":
+		return &BlindPosted{}, nil
+	case "HoleCardsDealt":
+		return &HoleCardsDealt{}, nil
+	case "TurnStarted":
+		return &TurnStarted{}, nil
+	case "ActionTaken":
+		return &ActionTaken{}, nil
+	case "StreetDealt":
+		return &StreetDealt{}, nil
+	case "RunItOffered":
+		return &RunItOffered{}, nil
+	case "ShowdownRevealed":
+		return &ShowdownRevealed{}, nil
+	case "HandMucked":
+		return &HandMucked{}, nil
+	case "BetReturned":
+		return &BetReturned{}, nil
+	case "PotAwarded":
+		return &PotAwarded{}, nil
+	default:
+		return nil, i18n.Errorf(msgUnsupportedSavedEvent, name)
+	}
+}
+
+// ResumeHand は再開した進行中のハンドのこれまでのイベントを、登録したリスナーに送り直す
+// イベントはハンドログに記録済みのため、記録し直さない
+func (p *Poker) ResumeHand() {
+	log := p.CurrentHandLog()
+	if log == nil || log.IsComplete {
+		return
+	}
+	for _, e := range log.Events {
+		for _, l := range p.Listeners {
+			l.OnEvent(e)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package poker
+
+import (
+	"encoding/json"
+	"errors"
+	"go_poker/i18n"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// playSameActions は2つのゲームの手番のプレイヤーに同じアクションをさせる
+func playSameActions(t *testing.T, games []*Poker, actions ...ActionType) {
+	t.Helper()
+	for _, a := range actions {
+		for _, p := range games {
+			act(t, p, Action{Type: a})
+		}
+	}
+}
+
+func TestSaveGameRoundTrip(t *testing.T) {
+	p := newTestPoker(t, 100, 50, 3000, 3000, 3000)
+	p.Schedule = &BlindSchedule{Levels: []BlindLevel{{SmallBlind: 50, BigBlind: 100, Minutes: 30}, {SmallBlind: 100, BigBlind: 200, Minutes: 30}}}
+	p.StartHand()
+	// レベルの途中で、プリフロップのアクションの途中に中断する
+	p.LevelStartedAt = time.Now().Add(-12 * time.Minute)
+	act(t, p, Action{Type: Call})
+
+	path := filepath.Join(t.TempDir(), "game.json")
+	if err := p.SaveGame(path); err != nil {
+		t.Fatal(err)
+	}
+	q, err := LoadGame(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if elapsed := time.Since(q.LevelStartedAt); elapsed < 12*time.Minute || elapsed > 12*time.Minute+time.Minute {
+		t.Errorf("level elapsed = %s, want 12m", elapsed)
+	}
+	if q.Level != p.Level || q.BigBlind != p.BigBlind {
+		t.Errorf("level = %d (%d), want %d (%d)", q.Level, q.BigBlind, p.Level, p.BigBlind)
+	}
+
+	// 進行中のハンドのイベントが同じ順に戻り、同じ状態を作り直せる
+	want, got := p.CurrentHandLog(), q.CurrentHandLog()
+	if got == nil || len(got.Events) != len(want.Events) {
+		t.Fatalf("resumed events = %v, want %d events", got, len(want.Events))
+	}
+	for i, e := range want.Events {
+		if got.Events[i].String() != e.String() || got.Events[i].Header().Sequence != e.Header().Sequence {
+			t.Errorf("event %d = %s, want %s", i, got.Events[i], e)
+		}
+	}
+	wantState, err := want.State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotState, err := got.State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, seat := range wantState.Seats {
+		if g := gotState.Seats[i]; g.Stack != seat.Stack || g.TotalBet != seat.TotalBet || formatCards(g.HoleCards) != formatCards(seat.HoleCards) {
+			t.Errorf("resumed seat %d = %+v, want %+v", i, *g, *seat)
+		}
+	}
+	events := collectEvents(q)
+	q.ResumeHand()
+	if len(*events) != len(want.Events) {
+		t.Errorf("ResumeHand sent %d events, want %d", len(*events), len(want.Events))
+	}
+
+	// デッキと乱数源の状態が戻り、続きも次のハンドも同じカードが配られる
+	games := []*Poker{p, q}
+	playSameActions(t, games, Call, Check)
+	for !p.IsHandFinished {
+		playSameActions(t, games, Check)
+	}
+	if formatCards(q.Flop) != formatCards(p.Flop) {
+		t.Errorf("resumed board = %s, want %s", formatCards(q.Flop), formatCards(p.Flop))
+	}
+	for _, g := range games {
+		if err := g.NextHand(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, player := range p.Players {
+		if formatCards(q.Players[i].Hand.Cards) != formatCards(player.Hand.Cards) || q.Players[i].Money != player.Money {
+			t.Errorf("seat %d next hand = %s %d, want %s %d", i, formatCards(q.Players[i].Hand.Cards), q.Players[i].Money, formatCards(player.Hand.Cards), player.Money)
+		}
+	}
+}
+
+func TestLoadGameRejectsInvalidFile(t *testing.T) {
+	p := newTestPoker(t, 100, 50, 3000, 3000, 3000)
+	p.StartHand()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "game.json")
+	if err := p.SaveGame(path); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		key   string
+		value interface{}
+		want  string
+	}{
+		{"対応していないバージョン", "Version", 99, ErrSavedGameVersion.With(99).Error()},
+		{"ボタンの座席が範囲外", "Button", 3, i18n.Errorf(msgNoSeat, 4).Error()},
+		{"手番の座席が負", "TurnIndex", -1, i18n.Errorf(msgNoSeat, 0).Error()},
+		{"ストラドルの座席が範囲外", "StraddleIndexes", []int{5}, i18n.Errorf(msgNoSeat, 6).Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var saved map[string]interface{}
+			if err := json.Unmarshal(data, &saved); err != nil {
+				t.Fatal(err)
+			}
+			saved[tt.key] = tt.value
+			broken, err := json.Marshal(saved)
+			if err != nil {
+				t.Fatal(err)
+			}
+			brokenPath := filepath.Join(dir, tt.key+".json")
+			if err := os.WriteFile(brokenPath, broken, 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, err = LoadGame(brokenPath)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("LoadGame error = %v, want %s", err, tt.want)
+			}
+			if tt.key == "Version" && !errors.Is(err, ErrSavedGameVersion) {
+				t.Errorf("LoadGame error = %v, want ErrSavedGameVersion", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package poker
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	MinSeats = 2
+	MaxSeats = 10
+)
+
+type Controller int
+
+const (
+	Human Controller = iota + 1
+	Bot
+)
+
+func (c Controller) String() string {
+	switch c {
+	case Human:
+		return "Human"
+	case Bot:
+		return "Bot"
+	default:
+		return "Unknown"
+	}
+}
+
+// Seat はテーブルに着席するプレイヤーの設定
+type Seat struct {
+	Name       string
+	Money      int
+	Controller Controller
+}
+
+// ParseSeats は "名前:所持金:human|bot" をカンマ区切りで並べた文字列から座席を作成する
+func ParseSeats(s string) ([]Seat, error) {
+	var seats []Seat
+	for _, item := range strings.Split(s, ",") {
+		fields := strings.Split(strings.TrimSpace(item), ":")
+		if len(fields) != 3 {
+			return nil, ErrInvalidSeat.With(item)
+		}
+		money, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, ErrInvalidSeatMoney.With(item)
+		}
+		var controller Controller
+		switch strings.ToLower(fields[2]) {
+		case "human":
+			controller = Human
+		case "bot":
+			controller = Bot
+		default:
+			return nil, ErrInvalidController.With(item)
+		}
+		seats = append(seats, Seat{
+			Name:       fields[0],
+			Money:      money,
+			Controller: controller,
+		})
+	}
+	return seats, nil
+}
+
+// DefaultSeats は1人の人間プレイヤーと残りをBotで埋めた座席を作成する
+func DefaultSeats(count, initMoney int) []Seat {
+	seats := []Seat{{Name: "Player", Money: initMoney, Controller: Human}}
+	for i := 1; i < count; i++ {
+		name := "Enemy"
+		if count > 2 {
+			name += strconv.Itoa(i)
+		}
+		seats = append(seats, Seat{Name: name, Money: initMoney, Controller: Bot})
+	}
+	return seats
+}
+
+func validateSeats(seats []Seat, bb int) error {
+	if len(seats) < MinSeats || len(seats) > MaxSeats {
+		return ErrPlayerCount.With(MinSeats, MaxSeats)
+	}
+	names := make(map[string]bool, len(seats))
+	for _, seat := range seats {
+		if seat.Name == "" {
+			return ErrNoPlayerName
+		}
+		if names[seat.Name] {
+			return ErrDuplicateName.With(seat.Name)
+		}
+		names[seat.Name] = true
+		if seat.Money < bb {
+			return ErrSeatMoneyTooSmall
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package poker
+
+import (
+	"go_poker/card"
+)
+
+// showdownOrder はショーダウンで手を見せる順に、ハンドに残ったプレイヤーを返す
+// 最後のラウンドで最後にベットかレイズをしたプレイヤーから見せ、いなければボタンの次のプレイヤーから時計回りに見せる
+func (p *Poker) showdownOrder() []*Player {
+	start := p.LastAggressorIndex
+	if start < 0 || !p.Players[start].isInHand() {
+		start = p.Button + 1
+	}
+	players := make([]*Player, 0, len(p.Players))
+	for i := 0; i < len(p.Players); i++ {
+		if player := p.Players[(start+i)%len(p.Players)]; player.isInHand() {
+			players = append(players, player)
+		}
+	}
+	return players
+}
+
+// canMuck はショーダウンでプレイヤーが手を見せずに捨てられるかを返す
+// 参加している全てのポットで、既に見せた手に負けている場合だけ捨てられる
+// オールインしたプレイヤーと、ボードを複数回配ったハンドでは必ず手を見せる
+func (p *Poker) canMuck(player *Player, shown []*Player) bool {
+	if player.isAllIn() || len(p.Runouts) > 0 {
+		return false
+	}
+	for _, pot := range p.Pots() {
+		if !containsPlayer(pot.Eligibles, player) {
+			continue
+		}
+		isBeaten := false
+		for _, other := range shown {
+			if containsPlayer(pot.Eligibles, other) && other.Hand.Compare(&player.Hand) > 0 {
+				isBeaten = true
+				break
+			}
+		}
+		if !isBeaten {
+			return false
+		}
+	}
+	return true
+}
+
+func containsPlayer(players []*Player, player *Player) bool {
+	for _, p := range players {
+		if p == player {
+			return true
+		}
+	}
+	return false
+}
+
+// CanShowCards は全員がフォールドしてポットを獲得したプレイヤーが、ハンドの終了後にホールカードを見せられるかを返す
+// 見せられるのは1ハンドに1回だけ
+func (p *Poker) CanShowCards(player *Player) bool {
+	players := p.getNotFoldPlayers()
+	if !p.IsHandFinished || len(players) != 1 || players[0] != player {
+		return false
+	}
+	if log := p.CurrentHandLog(); log != nil {
+		for _, e := range log.Events {
+			if _, ok := e.(*CardsShown); ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ShowCards は全員がフォールドしてポットを獲得したプレイヤーのホールカードを、ハンドの終了後に見せる
+func (p *Poker) ShowCards(player *Player) error {
+	if !p.CanShowCards(player) {
+		return ErrCannotShowCards
+	}
+	player.Hand.Culc(p.Flop)
+	p.emit(&CardsShown{Seat: p.seatIndex(player), Name: player.Name, Cards: append([]card.Card(nil), player.Hand.Cards...), Point: player.Hand.Point})
+	return nil
+}
@@ -0,0 +1,83 @@
+package poker
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// firstShown はショーダウンで最初に手を見せたプレイヤーの座席を返す
+func firstShown(events []Event) int {
+	for _, e := range events {
+		if e, ok := e.(*ShowdownRevealed); ok {
+			return e.Seat
+		}
+	}
+	return -1
+}
+
+func TestShowdownOrderAfterResume(t *testing.T) {
+	p := newTestPoker(t, 100, 50, 3000, 3000, 3000)
+	p.StartHand()
+	act(t, p, Action{Type: Call})
+	act(t, p, Action{Type: Call})
+	act(t, p, Action{Type: Check})
+	// フロップのベッターは、アクションのあった後のラウンドでアグレッサーではなくなる
+	flopBettor := p.TurnIndex
+	act(t, p, Action{Type: Raise, Bet: 200})
+	act(t, p, Action{Type: Call})
+	act(t, p, Action{Type: Call})
+	for i := 0; i < 3; i++ {
+		act(t, p, Action{Type: Check})
+	}
+	act(t, p, Action{Type: Check})
+	riverBettor := p.TurnIndex
+	if riverBettor == flopBettor {
+		t.Fatal("the river bettor must differ from the flop bettor")
+	}
+	act(t, p, Action{Type: Raise, Bet: 300})
+	if p.LastAggressorIndex != riverBettor {
+		t.Fatalf("LastAggressorIndex = %d, want %d", p.LastAggressorIndex, riverBettor)
+	}
+
+	// 再開したゲームでハンドログが残っていなくても、リバーのベッターから手を見せる
+	path := filepath.Join(t.TempDir(), "game.json")
+	if err := p.SaveGame(path); err != nil {
+		t.Fatal(err)
+	}
+	q, err := LoadGame(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q.HandLogs = nil
+	events := collectEvents(q)
+	act(t, q, Action{Type: Call})
+	act(t, q, Action{Type: Call})
+	if !q.IsHandFinished {
+		t.Fatal("the hand did not reach showdown")
+	}
+	if got := firstShown(*events); got != riverBettor {
+		t.Errorf("first shown seat = %d, want river bettor %d", got, riverBettor)
+	}
+}
+
+func TestShowdownOrderWithoutRiverBet(t *testing.T) {
+	p := newTestPoker(t, 100, 50, 3000, 3000, 3000)
+	events := collectEvents(p)
+	p.StartHand()
+	act(t, p, Action{Type: Call})
+	act(t, p, Action{Type: Call})
+	act(t, p, Action{Type: Check})
+	act(t, p, Action{Type: Raise, Bet: 200})
+	act(t, p, Action{Type: Call})
+	act(t, p, Action{Type: Call})
+	for i := 0; i < 6; i++ {
+		act(t, p, Action{Type: Check})
+	}
+	if !p.IsHandFinished {
+		t.Fatal("the hand did not reach showdown")
+	}
+	// リバーで誰もベットしなければ、ボタンの次のプレイヤーから手を見せる
+	if want := (p.Button + 1) % len(p.Players); firstShown(*events) != want {
+		t.Errorf("first shown seat = %d, want %d", firstShown(*events), want)
+	}
+}
@@ -0,0 +1,102 @@
+package poker
+
+import (
+	"go_poker/card"
+	"sort"
+)
+
+// OddChipRule は分割できずに余ったチップを誰に配るかのルール
+type OddChipRule int
+
+const (
+	// OddChipLeftOfButton はボタンの左隣から時計回りに近い勝者へ配る
+	OddChipLeftOfButton OddChipRule = iota
+	// OddChipHighSuit は最も強いホールカードを持つ勝者へ配る
+	// 同じ数字ならスペード、ハート、ダイヤ、クラブの順に強い
+	OddChipHighSuit
+)
+
+func (r OddChipRule) String() string {
+	switch r {
+	case OddChipLeftOfButton:
+		return "LeftOfButton"
+	case OddChipHighSuit:
+		return "HighSuit"
+	default:
+		return "Unknown"
+	}
+}
+
+// splitPot はポットを勝者の人数で等分した額を勝者の順に返す
+// 等分はChipUnitの単位で行い、余ったチップは1単位ずつOddChipRuleの順に配る
+func (p *Poker) splitPot(amount int, winPlayers []*Player) []int {
+	unit := p.ChipUnit
+	if unit <= 0 {
+		unit = 1
+	}
+	shares := make([]int, len(winPlayers))
+	share := amount / len(winPlayers) / unit * unit
+	for i := range shares {
+		shares[i] = share
+	}
+
+	oddChips := amount - share*len(winPlayers)
+	order := p.oddChipOrder(winPlayers)
+	for i := 0; oddChips > 0; i = (i + 1) % len(order) {
+		chip := unit
+		if chip > oddChips {
+			chip = oddChips
+		}
+		shares[order[i]] += chip
+		oddChips -= chip
+	}
+	return shares
+}
+
+// oddChipOrder は余ったチップを受け取る順に、勝者のインデックスを並べて返す
+func (p *Poker) oddChipOrder(winPlayers []*Player) []int {
+	order := make([]int, len(winPlayers))
+	for i := range order {
+		order[i] = i
+	}
+
+	switch p.OddChipRule {
+	case OddChipHighSuit:
+		sort.SliceStable(order, func(i, j int) bool {
+			return isHigherCard(highCard(winPlayers[order[i]]), highCard(winPlayers[order[j]]))
+		})
+	default:
+		sort.SliceStable(order, func(i, j int) bool {
+			return p.distanceFromButton(winPlayers[order[i]]) < p.distanceFromButton(winPlayers[order[j]])
+		})
+	}
+	return order
+}
+
+// distanceFromButton はボタンから時計回りに数えた座席の距離を返す
+func (p *Poker) distanceFromButton(player *Player) int {
+	for i := 1; i <= len(p.Players); i++ {
+		if p.Players[(p.Button+i)%len(p.Players)] == player {
+			return i
+		}
+	}
+	return len(p.Players)
+}
+
+func highCard(player *Player) card.Card {
+	var result card.Card
+	for _, c := range player.Hand.Cards {
+		if result.Number == 0 || isHigherCard(c, result) {
+			result = c
+		}
+	}
+	return result
+}
+
+func isHigherCard(a, b card.Card) bool {
+	if a.Number.Rank() != b.Number.Rank() {
+		return a.Number.Rank() > b.Number.Rank()
+	}
+	// スートの定数は強い順に並んでいる
+	return a.Suit < b.Suit
+}
@@ -0,0 +1,109 @@
+package poker
+
+import (
+	"go_poker/card"
+	"go_poker/hand"
+	"testing"
+)
+
+// parseCards はテスト用のカードの表記を読み込む
+func parseCards(t *testing.T, s string) []card.Card {
+	t.Helper()
+	cards, err := card.ParseCards(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cards
+}
+
+func TestSplitPotOddChips(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   OddChipRule
+		unit   int
+		amount int
+		want   []int
+	}{
+		// ボタンは座席2なので、座席3、座席0、座席1の順に余りを受け取る
+		{"ボタンの左から1チップずつ", OddChipLeftOfButton, 1, 1001, []int{334, 333, 334}},
+		{"ボタンの左からチップの単位で", OddChipLeftOfButton, 25, 1000, []int{325, 325, 350}},
+		// ホールカードはAs、Ah、Acの順に強い
+		{"強いスートから1チップずつ", OddChipHighSuit, 1, 1001, []int{334, 334, 333}},
+		{"強いスートからチップの単位で", OddChipHighSuit, 25, 1000, []int{325, 350, 325}},
+		{"割り切れれば余りはない", OddChipHighSuit, 25, 1050, []int{350, 350, 350}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestPoker(t, 200, 100, 3000, 3000, 3000, 3000)
+			p.Button = 2
+			p.OddChipRule = tt.rule
+			p.ChipUnit = tt.unit
+			p.Players[0].Hand = *hand.NewHand(parseCards(t, "Ah Kd"))
+			p.Players[1].Hand = *hand.NewHand(parseCards(t, "As Kh"))
+			p.Players[3].Hand = *hand.NewHand(parseCards(t, "Ac Kc"))
+			winners := []*Player{p.Players[0], p.Players[1], p.Players[3]}
+
+			got := p.splitPot(tt.amount, winners)
+			total := 0
+			for i := range tt.want {
+				total += got[i]
+				if got[i] != tt.want[i] {
+					t.Fatalf("splitPot = %v, want %v", got, tt.want)
+				}
+			}
+			if total != tt.amount {
+				t.Errorf("total = %d, want %d", total, tt.amount)
+			}
+		})
+	}
+}
+
+func TestFinishSplitPots(t *testing.T) {
+	tests := []struct {
+		name  string
+		board string
+		holes []string
+		bets  []int
+		money []int
+	}{
+		{
+			name:  "同じ手役でポットを等分する",
+			board: "Qs Jc 8h 4d 2s",
+			holes: []string{"Ah Kd", "As Kh", "3c 5d"},
+			bets:  []int{1000, 1000, 1000},
+			money: []int{3500, 3500, 2000},
+		},
+		{
+			name:  "メインポットとサイドポットの勝者が違う",
+			board: "Qs Jc 8h 4d 2s",
+			holes: []string{"Ah Kd", "As Kh", "Qc Qd"},
+			bets:  []int{1000, 1000, 400},
+			money: []int{2600, 2600, 3800},
+		},
+		{
+			name:  "ボードの役を全員で分ける",
+			board: "Tc Jc Qc Kc Ac",
+			holes: []string{"2h 3d", "4s 5h", "6c 7d"},
+			bets:  []int{500, 500, 500},
+			money: []int{3000, 3000, 3000},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestPoker(t, 200, 100, 3000, 3000, 3000)
+			p.Flop = parseCards(t, tt.board)
+			for i, player := range p.Players {
+				player.Hand = *hand.NewHand(parseCards(t, tt.holes[i]))
+				player.Hand.Culc(p.Flop)
+				player.Money -= tt.bets[i]
+				player.TotalBet = tt.bets[i]
+			}
+			p.Finish()
+			for i, player := range p.Players {
+				if player.Money != tt.money[i] {
+					t.Errorf("%s money = %d, want %d", player.Name, player.Money, tt.money[i])
+				}
+			}
+		})
+	}
+}
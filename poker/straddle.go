@@ -0,0 +1,103 @@
+package poker
+
+// StraddleType はプリフロップで任意に行えるストラドルの種類
+type StraddleType int
+
+const (
+	NoStraddle StraddleType = iota
+	// UTGStraddle はBBの次のプレイヤーがストラドルする
+	UTGStraddle
+	// ButtonStraddle はボタンのプレイヤーがストラドルし、プリフロップはSBから始まる
+	ButtonStraddle
+)
+
+func (s StraddleType) String() string {
+	switch s {
+	case NoStraddle:
+		return "None"
+	case UTGStraddle:
+		return "UTG"
+	case ButtonStraddle:
+		return "Button"
+	default:
+		return "Unknown"
+	}
+}
+
+// ParseStraddleType は "none", "utg", "button" からストラドルの種類を返す
+func ParseStraddleType(s string) (StraddleType, bool) {
+	switch s {
+	case "none", "":
+		return NoStraddle, true
+	case "utg":
+		return UTGStraddle, true
+	case "button":
+		return ButtonStraddle, true
+	default:
+		return NoStraddle, false
+	}
+}
+
+// postStraddles はストラドルを希望するプレイヤーにストラドルを支払わせる
+// ストラドルは直前のベット額の2倍を支払うライブマネーで、次のBBとして扱われる
+// 再ストラドルは直前にストラドルしたプレイヤーの左隣へ続き、ブラインドのプレイヤーはストラドルできない
+func (p *Poker) postStraddles() {
+	p.StraddleIndexes = nil
+	if p.Straddle == NoStraddle || p.Structure == FixedLimit || p.countSeatedPlayers() < 3 {
+		return
+	}
+
+	idx := p.nextSeatedIndex(p.BigBlindIndex)
+	if p.Straddle == ButtonStraddle {
+		if p.IsDeadButton() {
+			return
+		}
+		idx = p.Button
+	}
+
+	straddle := p.BigBlind * 2
+	for i := 0; i <= p.MaxReStraddles; i++ {
+		player := p.Players[idx]
+		if idx == p.SmallBlindIndex || idx == p.BigBlindIndex || p.IsStraddler(player) {
+			break
+		}
+		if player.Money <= straddle || !p.wantsStraddle(player) {
+			break
+		}
+		p.postBlind(idx, StraddlePost, straddle)
+		p.StraddleIndexes = append(p.StraddleIndexes, idx)
+		p.TurnBet = straddle
+		p.LastRaise = straddle
+
+		idx = p.nextSeatedIndex(idx)
+		straddle *= 2
+	}
+}
+
+// lastBlindIndex はプリフロップで最後に強制ベットを支払ったプレイヤーの座席を返す
+// ストラドルがあれば最後のストラドル、なければBBの座席になる
+func (p *Poker) lastBlindIndex() int {
+	if len(p.StraddleIndexes) > 0 {
+		return p.StraddleIndexes[len(p.StraddleIndexes)-1]
+	}
+	return p.BigBlindIndex
+}
+
+// IsStraddler はプレイヤーがこのハンドでストラドルしたかを返す
+func (p *Poker) IsStraddler(player *Player) bool {
+	for _, idx := range p.StraddleIndexes {
+		if p.Players[idx] == player {
+			return true
+		}
+	}
+	return false
+}
+
+// wantsStraddle はプレイヤーがストラドルを希望しているかを返す
+// Botはランダムにストラドルするかを決める
+func (p *Poker) wantsStraddle(player *Player) bool {
+	if player.IsBot() {
+		return p.source().Rand().Intn(3) == 0
+	}
+	return player.WantsStraddle
+}
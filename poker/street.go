@@ -0,0 +1,111 @@
+package poker
+
+// Street はハンドの進行段階
+type Street int
+
+const (
+	StreetPreFlop Street = iota + 1
+	StreetFlop
+	StreetTurn
+	StreetRiver
+	StreetShowDown
+)
+
+func (s Street) String() string {
+	switch s {
+	case StreetPreFlop:
+		return "PreFlop"
+	case StreetFlop:
+		return "Flop"
+	case StreetTurn:
+		return "Turn"
+	case StreetRiver:
+		return "River"
+	case StreetShowDown:
+		return "ShowDown"
+	default:
+		return "Unknown"
+	}
+}
+
+// NextStreet はベッティングラウンドを閉じて次のストリートへ進める
+// 誰にもコールされなかったベットは、ポットを作る前にベットしたプレイヤーに戻す
+// アクションできるプレイヤーがいなければ、ショーダウンまで続けてボードを開く
+func (p *Poker) NextStreet() *Poker {
+	p.returnUncalledBet()
+	// 1人を残して全員がフォールドした場合
+	if len(p.getNotFoldPlayers()) == 1 {
+		p.Finish()
+		return p
+	}
+	// 全員がオールインした場合は、残りのボードを配るだけになる
+	if p.Street < StreetRiver && p.isAllInRunout() {
+		p.offerRunIt()
+		return p
+	}
+
+	switch p.Street {
+	case StreetPreFlop:
+		p.Street = StreetFlop
+	case StreetFlop:
+		p.Street = StreetTurn
+	case StreetTurn:
+		p.Street = StreetRiver
+	default:
+		p.Street = StreetShowDown
+		p.ShowDown()
+		return p
+	}
+	p.startBettingRound()
+	p.OpenFlop()
+
+	if p.isRoundClosed() {
+		return p.NextStreet()
+	}
+	p.TurnIndex = p.firstActionIndex()
+	p.emit(&TurnStarted{Seat: p.TurnIndex, Name: p.getCurrentPlayer().Name})
+	p.playBot()
+	return p
+}
+
+// startBettingRound はストリートごとのベット額とアクション済みの状態をリセットする
+func (p *Poker) startBettingRound() {
+	for _, player := range p.Players {
+		player.CurrentBet = 0
+		player.ActedBet = 0
+		player.HasActed = false
+		if player.isInHand() {
+			player.CurrentAction = Action{}
+		}
+	}
+	p.TurnBet = 0
+	p.LastRaise = p.initialRaise()
+	p.RaiseCount = 0
+	p.RoundAggressorIndex = -1
+}
+
+// firstActionIndex はストリートで最初にアクションするプレイヤーの座席を返す
+// プリフロップはBB(ストラドルがあれば最後のストラドル)の次から、フロップ以降はボタンの次から始まる
+// ヘッズアップではボタンがSBを兼ねるため、プリフロップはボタンが先にアクションする
+func (p *Poker) firstActionIndex() int {
+	if p.Street == StreetPreFlop {
+		return p.nextActionIndex(p.lastBlindIndex())
+	}
+	return p.nextActionIndex(p.Button)
+}
+
+// isRoundClosed はベッティングラウンドが閉じているかを返す
+// アクションできる全員が、最後のレイズ以降にアクションしてベット額を揃えていれば閉じる
+func (p *Poker) isRoundClosed() bool {
+	players := p.getActionablePlayers()
+	// 相手が全員オールインかフォールドしていれば、ベット額を揃えるだけでよい
+	if len(players) == 1 {
+		return players[0].CurrentBet >= p.TurnBet
+	}
+	for _, player := range players {
+		if !player.HasActed || player.CurrentBet < p.TurnBet {
+			return false
+		}
+	}
+	return true
+}
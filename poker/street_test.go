@@ -0,0 +1,135 @@
+package poker
+
+import (
+	"testing"
+)
+
+func TestBigBlindOption(t *testing.T) {
+	p := newTestPoker(t, 100, 50, 3000, 3000, 3000)
+	p.StartHand()
+	act(t, p, Action{Type: Call})
+	act(t, p, Action{Type: Call})
+
+	// 全員がBBの額に揃っていても、BBはまだアクションしていない
+	if p.Street != StreetPreFlop || p.TurnIndex != p.BigBlindIndex {
+		t.Fatalf("street = %s, turn = %d, want PreFlop and the BB %d", p.Street, p.TurnIndex, p.BigBlindIndex)
+	}
+	if p.isRoundClosed() {
+		t.Fatal("the round closed before the BB used the option")
+	}
+	legal := p.LegalActions(p.getCurrentPlayer())
+	if !legal.Can(Check) || !legal.Can(Raise) {
+		t.Errorf("BB option = %+v, want check and raise", legal)
+	}
+	act(t, p, Action{Type: Check})
+
+	if p.Street != StreetFlop {
+		t.Fatalf("street = %s, want Flop", p.Street)
+	}
+	// フロップ以降はボタンの次から始まる
+	if want := p.nextActionIndex(p.Button); p.TurnIndex != want {
+		t.Errorf("first to act on the flop = %d, want %d", p.TurnIndex, want)
+	}
+}
+
+func TestRaiseReopensAction(t *testing.T) {
+	p := newTestPoker(t, 100, 50, 3000, 3000, 3000)
+	p.StartHand()
+	limper := p.getCurrentPlayer()
+	act(t, p, Action{Type: Call})
+	act(t, p, Action{Type: Call})
+	act(t, p, Action{Type: Raise, Bet: 400})
+
+	// BBのレイズで、既にアクションしたプレイヤーに再び手番が回る
+	if p.Street != StreetPreFlop || p.getCurrentPlayer() != limper {
+		t.Fatalf("street = %s, turn = %s, want PreFlop and %s", p.Street, p.getCurrentPlayer().Name, limper.Name)
+	}
+	if limper.HasActed {
+		t.Error("the raise did not reset HasActed")
+	}
+	act(t, p, Action{Type: Call})
+	if p.Street != StreetPreFlop || p.isRoundClosed() {
+		t.Fatal("the round closed before the SB answered the raise")
+	}
+	act(t, p, Action{Type: Call})
+	if p.Street != StreetFlop {
+		t.Errorf("street = %s, want Flop", p.Street)
+	}
+}
+
+func TestShortAllInClosesRound(t *testing.T) {
+	p := newTestPoker(t, 100, 50, 3000, 400, 3000)
+	p.StartHand()
+	opener := p.getCurrentPlayer()
+	act(t, p, Action{Type: Raise, Bet: 300})
+	act(t, p, Action{Type: AllIn})
+	act(t, p, Action{Type: Call})
+
+	// 最低レイズ額に満たないオールインの後は、オープナーがコールすればラウンドが閉じる
+	if p.Street != StreetPreFlop || p.getCurrentPlayer() != opener {
+		t.Fatalf("street = %s, turn = %s, want PreFlop and %s", p.Street, p.getCurrentPlayer().Name, opener.Name)
+	}
+	act(t, p, Action{Type: Call})
+	if p.Street != StreetFlop {
+		t.Fatalf("street = %s, want Flop", p.Street)
+	}
+	for _, player := range p.getActionablePlayers() {
+		if player.CurrentBet != 0 || player.HasActed {
+			t.Errorf("%s bet = %d, acted = %v after the new round started", player.Name, player.CurrentBet, player.HasActed)
+		}
+	}
+}
+
+func TestAllInSkipsToShowdown(t *testing.T) {
+	tests := []struct {
+		name    string
+		stacks  []int
+		actions []ActionType
+	}{
+		{"全員がオールイン", []int{1000, 1000}, []ActionType{AllIn, Call}},
+		{"アクションできるのが1人だけ", []int{500, 3000, 3000}, []ActionType{AllIn, Call, Fold}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestPoker(t, 100, 50, tt.stacks...)
+			events := collectEvents(p)
+			p.StartHand()
+			for _, a := range tt.actions {
+				act(t, p, Action{Type: a})
+			}
+			if !p.IsHandFinished || len(p.Flop) != 5 {
+				t.Fatalf("finished = %v, board = %d cards, want a finished hand with 5 cards", p.IsHandFinished, len(p.Flop))
+			}
+			streets := 0
+			afterFlop := false
+			for _, e := range *events {
+				switch e := e.(type) {
+				case *StreetDealt:
+					streets++
+					afterFlop = true
+				case *TurnStarted:
+					if afterFlop {
+						t.Fatalf("%s was asked to act after the board was run out", e.Name)
+					}
+				}
+			}
+			if streets != 3 {
+				t.Errorf("streets dealt = %d, want 3", streets)
+			}
+		})
+	}
+}
+
+func TestHeadsUpActionOrder(t *testing.T) {
+	p := newTestPoker(t, 100, 50, 3000, 3000)
+	p.StartHand()
+	// ヘッズアップではボタンがSBを兼ね、プリフロップは先に、フロップ以降は後にアクションする
+	if p.TurnIndex != p.Button || p.Button != p.SmallBlindIndex {
+		t.Fatalf("preflop turn = %d, button = %d, SB = %d, want the button first", p.TurnIndex, p.Button, p.SmallBlindIndex)
+	}
+	act(t, p, Action{Type: Call})
+	act(t, p, Action{Type: Check})
+	if p.Street != StreetFlop || p.TurnIndex != p.BigBlindIndex {
+		t.Errorf("street = %s, turn = %d, want Flop and the BB %d", p.Street, p.TurnIndex, p.BigBlindIndex)
+	}
+}
@@ -0,0 +1,66 @@
+package poker
+
+// BettingStructure はベット額の上限と下限を決めるルール
+type BettingStructure int
+
+const (
+	NoLimit BettingStructure = iota
+	PotLimit
+	FixedLimit
+)
+
+func (b BettingStructure) String() string {
+	switch b {
+	case NoLimit:
+		return "NoLimit"
+	case PotLimit:
+		return "PotLimit"
+	case FixedLimit:
+		return "FixedLimit"
+	default:
+		return "Unknown"
+	}
+}
+
+// ParseBettingStructure は "nl", "pl", "fl" の略称からベットのルールを返す
+func ParseBettingStructure(s string) (BettingStructure, bool) {
+	switch s {
+	case "nl", "NoLimit":
+		return NoLimit, true
+	case "pl", "PotLimit":
+		return PotLimit, true
+	case "fl", "FixedLimit":
+		return FixedLimit, true
+	default:
+		return NoLimit, false
+	}
+}
+
+// betSize はフィックスドリミットで現在のストリートのベット単位を返す
+// プリフロップとフロップはスモールベット、ターンとリバーはビッグベットになる
+func (p *Poker) betSize() int {
+	if p.Street == StreetTurn || p.Street == StreetRiver {
+		return p.BigBet
+	}
+	return p.SmallBet
+}
+
+// initialRaise はラウンド開始時の最低レイズ額を返す
+func (p *Poker) initialRaise() int {
+	if p.Structure == FixedLimit {
+		return p.betSize()
+	}
+	return p.BigBlind
+}
+
+// potLimitBet はポットリミットでレイズ後のベット額として指定できる最大値を返す
+// コールした後のポットの額だけ上乗せできる
+func (p *Poker) potLimitBet(player *Player) int {
+	callAmount := p.TurnBet - player.CurrentBet
+	return p.TurnBet + p.CulcPot() + callAmount
+}
+
+// isRaiseCapped はフィックスドリミットでレイズ回数の上限に達しているかを返す
+func (p *Poker) isRaiseCapped() bool {
+	return p.Structure == FixedLimit && p.RaiseCap > 0 && p.RaiseCount >= p.RaiseCap
+}
@@ -0,0 +1,284 @@
+package poker
+
+import (
+	"go_poker/i18n"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// tomlValue はPHHを読み込むためのTOMLの値と、その値が書かれた行
+// Valueは文字列、float64、bool、[]tomlValueのいずれかになる
+// 日付や時刻のような引用符のない値は文字列として扱う
+type tomlValue struct {
+	Line  int
+	Value interface{}
+}
+
+// tomlTable はTOMLの1つの表。ファイルの先頭の名前のない表も含む
+type tomlTable struct {
+	name   string
+	line   int
+	fields map[string]tomlValue
+}
+
+// tomlParser はPHHで使うTOMLの一部(キーと値、配列、表の見出し、コメント)を読み込む
+type tomlParser struct {
+	text []rune
+	pos  int
+	line int
+}
+
+func parseTOMLTables(text string) ([]*tomlTable, error) {
+	p := &tomlParser{text: []rune(text), line: 1}
+	table := &tomlTable{line: 1, fields: map[string]tomlValue{}}
+	tables := []*tomlTable{table}
+	for {
+		p.skipSpace(true)
+		if p.pos >= len(p.text) {
+			return tables, nil
+		}
+		line := p.line
+		if p.peek() == '[' {
+			name := strings.Trim(p.readLine(), "[] \t")
+			table = &tomlTable{name: name, line: line, fields: map[string]tomlValue{}}
+			tables = append(tables, table)
+			continue
+		}
+		key := strings.Trim(strings.TrimSpace(p.readUntil('=')), `"`)
+		if p.peek() != '=' || key == "" {
+			return nil, &ParseError{Line: line, Text: key, Message: i18n.T(msgNotKeyValue)}
+		}
+		p.pos++
+		p.skipSpace(false)
+		value, err := p.value()
+		if err != nil {
+			return nil, &ParseError{Line: p.line, Text: key, Message: err.Error()}
+		}
+		p.skipSpace(false)
+		if p.pos < len(p.text) && p.peek() != '\n' {
+			return nil, &ParseError{Line: p.line, Text: key, Message: i18n.T(msgTrailingCharacters)}
+		}
+		if _, ok := table.fields[key]; ok {
+			return nil, &ParseError{Line: line, Text: key, Message: i18n.T(msgDuplicateKey)}
+		}
+		table.fields[key] = value
+	}
+}
+
+func (p *tomlParser) peek() rune {
+	return p.text[p.pos]
+}
+
+// skipSpace は空白とコメントを読み飛ばす。withNewlineがtrueなら改行も読み飛ばす
+func (p *tomlParser) skipSpace(withNewline bool) {
+	for p.pos < len(p.text) {
+		switch r := p.peek(); {
+		case r == '#':
+			for p.pos < len(p.text) && p.peek() != '\n' {
+				p.pos++
+			}
+		case r == '\n' && withNewline:
+			p.line++
+			p.pos++
+		case r == ' ' || r == '\t' || r == '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *tomlParser) readLine() string {
+	return p.readUntil('\n')
+}
+
+func (p *tomlParser) readUntil(end rune) string {
+	start := p.pos
+	for p.pos < len(p.text) && p.peek() != end && p.peek() != '\n' {
+		p.pos++
+	}
+	return string(p.text[start:p.pos])
+}
+
+func (p *tomlParser) value() (tomlValue, error) {
+	result := tomlValue{Line: p.line}
+	if p.pos >= len(p.text) {
+		return result, i18n.Errorf(msgNoValue)
+	}
+	switch r := p.peek(); {
+	case r == '"':
+		start := p.pos
+		p.pos++
+		for p.pos < len(p.text) && p.peek() != '"' && p.peek() != '\n' {
+			if p.peek() == '\\' {
+				p.pos++
+			}
+			p.pos++
+		}
+		if p.pos >= len(p.text) || p.peek() != '"' {
+			return result, i18n.Errorf(msgUnterminatedString)
+		}
+		p.pos++
+		text, err := strconv.Unquote(string(p.text[start:p.pos]))
+		if err != nil {
+			return result, i18n.Errorf(msgInvalidString, string(p.text[start:p.pos]))
+		}
+		result.Value = text
+	case r == '\'':
+		p.pos++
+		text := p.readUntil('\'')
+		if p.pos >= len(p.text) || p.peek() != '\'' {
+			return result, i18n.Errorf(msgUnterminatedString)
+		}
+		p.pos++
+		result.Value = text
+	case r == '[':
+		p.pos++
+		values := []tomlValue{}
+		for {
+			p.skipSpace(true)
+			if p.pos >= len(p.text) {
+				return result, i18n.Errorf(msgUnterminatedArray)
+			}
+			if p.peek() == ']' {
+				p.pos++
+				break
+			}
+			item, err := p.value()
+			if err != nil {
+				return result, err
+			}
+			values = append(values, item)
+			p.skipSpace(true)
+			if p.pos < len(p.text) && p.peek() == ',' {
+				p.pos++
+			} else if p.pos < len(p.text) && p.peek() != ']' {
+				return result, i18n.Errorf(msgNoArraySeparator)
+			}
+		}
+		result.Value = values
+	default:
+		start := p.pos
+		for p.pos < len(p.text) {
+			r := p.peek()
+			if unicode.IsSpace(r) || r == ',' || r == ']' || r == '#' {
+				break
+			}
+			p.pos++
+		}
+		text := string(p.text[start:p.pos])
+		switch text {
+		case "":
+			return result, i18n.Errorf(msgNoValue)
+		case "true", "false":
+			result.Value = text == "true"
+		default:
+			if number, err := strconv.ParseFloat(strings.ReplaceAll(text, "_", ""), 64); err == nil {
+				result.Value = number
+			} else {
+				result.Value = text
+			}
+		}
+	}
+	return result, nil
+}
+
+func (t *tomlTable) errorAt(key string, err error) *ParseError {
+	line := t.line
+	if value, ok := t.fields[key]; ok {
+		line = value.Line
+	}
+	return &ParseError{Line: line, Text: key, Message: err.Error()}
+}
+
+func (t *tomlTable) field(key string) (tomlValue, error) {
+	value, ok := t.fields[key]
+	if !ok {
+		return value, &ParseError{Line: t.line, Text: key, Message: i18n.T(msgMissingKey)}
+	}
+	return value, nil
+}
+
+func (t *tomlTable) str(key string) (string, error) {
+	value, err := t.field(key)
+	if err != nil {
+		return "", err
+	}
+	text, ok := value.Value.(string)
+	if !ok {
+		return "", t.errorAt(key, i18n.Errorf(msgNotString))
+	}
+	return text, nil
+}
+
+func (t *tomlTable) number(key string) (float64, error) {
+	value, err := t.field(key)
+	if err != nil {
+		return 0, err
+	}
+	number, ok := value.Value.(float64)
+	if !ok {
+		return 0, t.errorAt(key, i18n.Errorf(msgNotNumber))
+	}
+	return number, nil
+}
+
+func (t *tomlTable) array(key string) ([]tomlValue, error) {
+	value, err := t.field(key)
+	if err != nil {
+		return nil, err
+	}
+	values, ok := value.Value.([]tomlValue)
+	if !ok {
+		return nil, t.errorAt(key, i18n.Errorf(msgNotArray))
+	}
+	return values, nil
+}
+
+func (t *tomlTable) numbers(key string) ([]float64, error) {
+	values, err := t.array(key)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]float64, 0, len(values))
+	for _, value := range values {
+		number, ok := value.Value.(float64)
+		if !ok {
+			return nil, &ParseError{Line: value.Line, Text: key, Message: i18n.T(msgNotNumber)}
+		}
+		result = append(result, number)
+	}
+	return result, nil
+}
+
+// optionalNumbers は数値の配列を返す。キーがなければ全て0の配列を返す
+func (t *tomlTable) optionalNumbers(key string, count int) ([]float64, error) {
+	if _, ok := t.fields[key]; !ok {
+		return make([]float64, count), nil
+	}
+	result, err := t.numbers(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) != count {
+		return nil, t.errorAt(key, i18n.Errorf(msgCountMismatch))
+	}
+	return result, nil
+}
+
+func (t *tomlTable) strings(key string) ([]string, error) {
+	values, err := t.array(key)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		text, ok := value.Value.(string)
+		if !ok {
+			return nil, &ParseError{Line: value.Line, Text: key, Message: i18n.T(msgNotString)}
+		}
+		result = append(result, text)
+	}
+	return result, nil
+}
@@ -0,0 +1,250 @@
+package poker
+
+import (
+	"fmt"
+	"go_poker/i18n"
+	"go_poker/icm"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Tournament はシングルテーブルトーナメント(Sit & Go)の設定と進行状況
+type Tournament struct {
+	BuyIn         int
+	StartingStack int
+	// Payouts は順位ごとの賞金の割合(%)で、1位から順に並ぶ
+	Payouts []int
+	// Eliminations は飛んだ順に並んだプレイヤー
+	Eliminations    []*Player
+	handStartStacks map[*Player]int
+}
+
+// Standing はトーナメントの最終順位
+type Standing struct {
+	Place  int
+	Player *Player
+	Prize  int
+}
+
+func NewTournament(buyIn, startingStack int, payouts []int) (*Tournament, error) {
+	if buyIn < 0 || startingStack <= 0 {
+		return nil, ErrInvalidTournament
+	}
+	total := 0
+	for _, payout := range payouts {
+		if payout <= 0 {
+			return nil, ErrInvalidPayout
+		}
+		total += payout
+	}
+	if total != 100 {
+		return nil, ErrPayoutTotal.With(total)
+	}
+	return &Tournament{
+		BuyIn:         buyIn,
+		StartingStack: startingStack,
+		Payouts:       payouts,
+	}, nil
+}
+
+// ParsePayouts は "50/30/20" のような形式の賞金の割合を読み込む
+func ParsePayouts(s string) ([]int, error) {
+	var payouts []int
+	for _, item := range strings.Split(s, "/") {
+		payout, err := strconv.Atoi(strings.TrimSpace(item))
+		if err != nil {
+			return nil, ErrInvalidPayouts.With(s)
+		}
+		payouts = append(payouts, payout)
+	}
+	return payouts, nil
+}
+
+// DefaultTournamentSchedule はスタート時のスタックから、10ハンドごとに上がるブラインドストラクチャーを作成する
+// 最初のBBはスタックの1/50で、5レベル目からアンテが加わる
+func DefaultTournamentSchedule(startingStack int) *BlindSchedule {
+	base := startingStack / 50 / 2 * 2
+	if base < 2 {
+		base = 2
+	}
+	multipliers := []int{1, 2, 3, 4, 6, 8, 10, 15, 20, 30, 40}
+	schedule := &BlindSchedule{}
+	for i, multiplier := range multipliers {
+		level := BlindLevel{
+			SmallBlind: base * multiplier / 2,
+			BigBlind:   base * multiplier,
+			Hands:      10,
+		}
+		if i >= 4 {
+			level.Ante = level.BigBlind / 8
+		}
+		if i == len(multipliers)-1 {
+			level.Hands = 0
+		}
+		schedule.Levels = append(schedule.Levels, level)
+	}
+	return schedule
+}
+
+// PrizePool はバイインの合計額を返す
+func (t *Tournament) PrizePool(entrants int) int {
+	return t.BuyIn * entrants
+}
+
+// Prizes は賞金の割合から順位ごとの賞金額を返す。端数は1位に加える
+func (t *Tournament) Prizes(entrants int) []int {
+	pool := t.PrizePool(entrants)
+	prizes := make([]int, len(t.Payouts))
+	paid := 0
+	for i, payout := range t.Payouts {
+		prizes[i] = pool * payout / 100
+		paid += prizes[i]
+	}
+	if len(prizes) > 0 {
+		prizes[0] += pool - paid
+	}
+	return prizes
+}
+
+// FutureGameHands は賞金の期待値を計算するときに、ブラインドを支払うとみなす将来のハンド数
+const FutureGameHands = 2
+
+// Equities は残っているプレイヤーのチップ量から、ICMで各プレイヤーの賞金の期待値を返す
+// 飛んだプレイヤーの賞金は順位で確定しているため、残っているプレイヤーは残りの順位の賞金だけを分け合う
+func (t *Tournament) Equities(players []*Player) []float64 {
+	return t.equities(players, icm.Equity)
+}
+
+// FutureGameEquities は次のFutureGameHandsハンドで現在のブラインドを支払うことを考慮して、各プレイヤーの賞金の期待値を返す
+// トーナメントでなければnilを返す
+func (p *Poker) FutureGameEquities() []float64 {
+	if p.Tournament == nil {
+		return nil
+	}
+	blinds := icm.Blinds{SmallBlind: p.SmollBlind, BigBlind: p.BigBlind, Ante: p.Ante}
+	if p.BigBlindAnte {
+		// BBアンテはBBが1人分のアンテを支払う
+		blinds.BigBlind += p.Ante
+		blinds.Ante = 0
+	}
+	game := icm.FutureGame{Button: p.nextSeatedIndex(p.Button)}
+	for i := 0; i < FutureGameHands; i++ {
+		game.Hands = append(game.Hands, blinds)
+	}
+	return p.Tournament.equities(p.Players, func(stacks, prizes []int) []float64 {
+		return icm.FutureGameEquity(stacks, prizes, game)
+	})
+}
+
+// equities はcalculateで残っているプレイヤーの期待値を計算し、飛んだプレイヤーには確定した賞金を返す
+func (t *Tournament) equities(players []*Player, calculate func(stacks, prizes []int) []float64) []float64 {
+	stacks := make([]int, len(players))
+	for i, player := range players {
+		if !t.isEliminated(player) {
+			stacks[i] = player.Money + player.TotalBet
+		}
+	}
+	prizes := t.Prizes(len(players))
+	remaining := len(players) - len(t.Eliminations)
+	if remaining > len(prizes) {
+		remaining = len(prizes)
+	}
+	equities := calculate(stacks, prizes[:remaining])
+	for i, player := range players {
+		if place := t.eliminatedPlace(player, len(players)); place > 0 && place <= len(prizes) {
+			equities[i] = float64(prizes[place-1])
+		}
+	}
+	return equities
+}
+
+// recordHandStart はハンド開始時の各プレイヤーのスタックを記録する
+func (t *Tournament) recordHandStart(players []*Player) {
+	t.handStartStacks = make(map[*Player]int, len(players))
+	for _, player := range players {
+		t.handStartStacks[player] = player.Money
+	}
+}
+
+// recordEliminations はハンドで飛んだプレイヤーを飛んだ順に記録する
+// 同じハンドで複数のプレイヤーが飛んだ場合は、ハンド開始時のスタックが多いプレイヤーが上位になる
+func (t *Tournament) recordEliminations(players []*Player) []*Player {
+	var eliminated []*Player
+	for _, player := range players {
+		if player.Money <= 0 && !t.isEliminated(player) {
+			eliminated = append(eliminated, player)
+		}
+	}
+	sort.SliceStable(eliminated, func(i, j int) bool {
+		return t.handStartStacks[eliminated[i]] < t.handStartStacks[eliminated[j]]
+	})
+	t.Eliminations = append(t.Eliminations, eliminated...)
+	return eliminated
+}
+
+func (t *Tournament) isEliminated(player *Player) bool {
+	for _, eliminated := range t.Eliminations {
+		if eliminated == player {
+			return true
+		}
+	}
+	return false
+}
+
+// eliminatedPlace は飛んだプレイヤーの順位を返す
+func (t *Tournament) eliminatedPlace(player *Player, entrants int) int {
+	for i, eliminated := range t.Eliminations {
+		if eliminated == player {
+			return entrants - i
+		}
+	}
+	return 0
+}
+
+// Standings は残っているプレイヤーをスタック順に、飛んだプレイヤーを飛んだ逆順に並べた順位を返す
+func (t *Tournament) Standings(players []*Player) []Standing {
+	var remaining []*Player
+	for _, player := range players {
+		if !t.isEliminated(player) {
+			remaining = append(remaining, player)
+		}
+	}
+	sort.SliceStable(remaining, func(i, j int) bool {
+		return remaining[i].Money > remaining[j].Money
+	})
+	for i := len(t.Eliminations) - 1; i >= 0; i-- {
+		remaining = append(remaining, t.Eliminations[i])
+	}
+
+	prizes := t.Prizes(len(players))
+	standings := make([]Standing, 0, len(remaining))
+	for i, player := range remaining {
+		standing := Standing{Place: i + 1, Player: player}
+		if i < len(prizes) {
+			standing.Prize = prizes[i]
+		}
+		standings = append(standings, standing)
+	}
+	return standings
+}
+
+// Report は最終順位と賞金の一覧を文字列で返す
+func (t *Tournament) Report(players []*Player) string {
+	var b strings.Builder
+	b.WriteString(i18n.T(msgFinalStandings, t.BuyIn, t.PrizePool(len(players))) + "\n")
+	for _, standing := range t.Standings(players) {
+		fmt.Fprintf(&b, "%2d. %-12s ＄%d\n", standing.Place, standing.Player.Name, standing.Prize)
+	}
+	return b.String()
+}
+
+// InterimReport はゲームの途中の順位とチップ量を、賞金を含めずに文字列で返す
+func (t *Tournament) InterimReport(players []*Player) string {
+	var b strings.Builder
+	b.WriteString(i18n.T(msgInterimStandings) + "\n")
+	for _, standing := range t.Standings(players) {
+		fmt.Fprintf(&b, "%2d. %-12s %d\n", standing.Place, standing.Player.Name, standing.Player.Money)
+	}
+	return b.String()
+}
@@ -0,0 +1,69 @@
+package poker
+
+import (
+	"go_poker/icm"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestFutureGameEquities(t *testing.T) {
+	p := newTestPoker(t, 1000, 500, 5000, 3000, 2000)
+	if got := p.FutureGameEquities(); got != nil {
+		t.Errorf("FutureGameEquities without a tournament = %v, want nil", got)
+	}
+
+	tournament, err := NewTournament(100, 5000, []int{50, 30, 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Tournament = tournament
+	got := p.FutureGameEquities()
+
+	// 次のハンドのボタンは座席1で、現在のブラインドを2ハンド支払う
+	blinds := icm.Blinds{SmallBlind: 500, BigBlind: 1000}
+	want := icm.FutureGameEquity([]int{5000, 3000, 2000}, tournament.Prizes(3), icm.FutureGame{Button: 1, Hands: []icm.Blinds{blinds, blinds}})
+	total := 0.0
+	for i := range want {
+		total += got[i]
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Fatalf("FutureGameEquities = %v, want %v", got, want)
+		}
+	}
+	if math.Abs(total-300) > 1e-9 {
+		t.Errorf("total equity = %f, want 300", total)
+	}
+
+	// BBアンテはエンジンと同じく、BBが1人分のアンテを支払う
+	p.Ante = 100
+	p.BigBlindAnte = true
+	got = p.FutureGameEquities()
+	blinds = icm.Blinds{SmallBlind: 500, BigBlind: 1100}
+	want = icm.FutureGameEquity([]int{5000, 3000, 2000}, tournament.Prizes(3), icm.FutureGame{Button: 1, Hands: []icm.Blinds{blinds, blinds}})
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Fatalf("FutureGameEquities with a BB ante = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestInterimReport(t *testing.T) {
+	p := newTestPoker(t, 100, 50, 5000, 3000, 2000)
+	tournament, err := NewTournament(100, 5000, []int{70, 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Tournament = tournament
+	report := tournament.InterimReport(p.Players)
+	if strings.Contains(report, "＄") {
+		t.Errorf("interim report shows prizes:\n%s", report)
+	}
+	for _, line := range []string{" 1. Player       5000\n", " 2. Enemy1       3000\n", " 3. Enemy2       2000\n"} {
+		if !strings.Contains(report, line) {
+			t.Errorf("missing %q in\n%s", line, report)
+		}
+	}
+	if !strings.Contains(tournament.Report(p.Players), "＄210") {
+		t.Errorf("final report has no prize:\n%s", tournament.Report(p.Players))
+	}
+}
@@ -1,35 +1,81 @@
 package poker
 
 import (
-	"errors"
 	"fmt"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"go_poker/card"
+	"go_poker/i18n"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type Viewer struct {
 	Context         *Poker
 	App             *tview.Application
+	rootFlex        *tview.Flex
 	turnText        *tview.TextView
 	potText         *tview.TextView
+	levelText       *tview.TextView
 	flopText        *tview.TextView
 	flopCardTable   *tview.Table
 	infoText        *tview.TextView
+	playerTableText *tview.TextView
 	playerCardTable *tview.Table
-	enemyCardTable  *tview.Table
 	playerMoneyText *tview.TextView
 	playerBetText   *tview.TextView
-	enemyMoneyText  *tview.TextView
-	enemyBetText    *tview.TextView
 	playerActions   *tview.List
+	raiseInput      *tview.InputField
+	runItInput      *tview.InputField
+	seatTable       *tview.Table
+	openedPlayers   map[*Player]bool
+	// equities はトーナメントの賞金の期待値で、スタックが確定するハンドの終了時に計算し直す
+	equities []float64
+}
+
+// NewViewer はゲームをTUIで表示するViewerを作成し、イベントを受け取るリスナーとして登録する
+func NewViewer(p *Poker) *Viewer {
+	v := &Viewer{
+		Context: p,
+		App:     tview.NewApplication(),
+	}
+	p.AddListener(v)
+	return v
+}
+
+// Start は画面を作成して最初のハンドを開始し、終了するまでTUIを動かす
+func (v *Viewer) Start() error {
+	if err := v.DrawInit(); err != nil {
+		return err
+	}
+	v.Context.StartHand()
+	return v.Run()
+}
+
+// Resume はLoadGameで再開したゲームの画面を作成し、中断したところからTUIを動かす
+// 進行中のハンドはこれまでのイベントを送り直して画面に表示する
+func (v *Viewer) Resume() error {
+	if err := v.DrawInit(); err != nil {
+		return err
+	}
+	switch {
+	case v.Context.HandCount == 0:
+		v.Context.StartHand()
+	case v.Context.IsHandFinished:
+		v.WriteInfoText(i18n.T(msgNextHandPrompt))
+	default:
+		v.Context.ResumeHand()
+	}
+	return v.Run()
 }
 
 func (v *Viewer) DrawInit() error {
-	if len(v.Context.Players) < 2 {
-		return errors.New("プレイヤー数が不足しています。")
+	if len(v.Context.Players) < MinSeats {
+		return i18n.Errorf(msgTooFewPlayers)
 	}
+	v.openedPlayers = make(map[*Player]bool, len(v.Context.Players))
+	v.equities = v.Context.FutureGameEquities()
 
 	cp := v.Context.getCurrentPlayer()
 	// ターン経過用テキスト
@@ -50,21 +96,30 @@ func (v *Viewer) DrawInit() error {
 		}).SetTextAlign(tview.AlignCenter)
 	v.potText.SetTitle("Pot").SetBorder(true).SetTitleColor(tcell.ColorYellow)
 
+	// ブラインドレベル確認用テキスト
+	v.levelText = tview.NewTextView().
+		SetTextColor(tcell.ColorWhite).
+		SetTextAlign(tview.AlignCenter)
+	v.levelText.SetTitle("Blinds").SetBorder(true).SetTitleColor(tcell.ColorYellow)
+
 	// フロップカード
-	v.flopCardTable = v.createCardTable(v.Context.GetFlopStrings())
+	v.flopText = tview.NewTextView().SetText(v.Context.Street.String()).SetTextColor(tcell.ColorGreen).SetTextAlign(tview.AlignCenter)
+	v.flopCardTable = tview.NewTable().SetBorders(true)
+	v.setCardRows(v.flopCardTable, v.Context.GetBoardStrings())
+	v.setHuntedCells(v.flopCardTable, len(v.Context.Flop), v.Context.Hunted)
 
 	// Information用テキスト
 	v.infoText = tview.NewTextView().
-		SetText(fmt.Sprintf("%sのターンです。アクションを選択してください。", cp.Name)).
+		SetText(i18n.T(msgYourTurn, cp.Name) + "\n").
 		SetTextColor(tcell.ColorOrange).
 		SetChangedFunc(func() {
 			v.App.Draw()
 		}).SetTextAlign(tview.AlignCenter)
-	v.infoText.SetTitle("Infomation").SetTitleColor(tcell.ColorRed).SetBorder(true)
+	v.infoText.SetTitle(i18n.T(msgInformationTitle)).SetTitleColor(tcell.ColorRed).SetBorder(true)
 
 	// プレイヤー
-	player := v.Context.Players[0]
-	playerTableText := tview.NewTextView().SetText("Player's Table").SetTextAlign(tview.AlignCenter)
+	player := v.viewPlayer()
+	v.playerTableText = tview.NewTextView().SetText(player.Name + "'s Table").SetTextAlign(tview.AlignCenter)
 	v.playerCardTable = v.createCardTable(player.GetHandStrings())
 
 	v.playerMoneyText = tview.NewTextView().
@@ -82,87 +137,119 @@ func (v *Viewer) DrawInit() error {
 
 	v.playerActions = tview.NewList().
 		AddItem(Fold.String(), "You hand fold", '1', func() {
-			v.infoText.SetText("")
-			v.Context.Action(Action{
+			v.doAction(Action{
 				Type: Fold,
 			})
-			v.Context.NextTurn()
-			v.DrawByCurrentData()
 		}).
 		AddItem(Call.String(), "You hand call", '2', func() {
-			v.infoText.SetText("")
-
-			err := v.Context.Action(Action{
+			v.doAction(Action{
 				Type: Call,
 			})
-			if err != nil {
-				v.infoText.Write([]byte(err.Error()))
-			} else {
-				v.infoText.Write([]byte(fmt.Sprintf("%sがCallを選択しました。\n", v.Context.getCurrentPlayer().Name)))
-				v.Context.NextPlayer()
-				v.DrawByCurrentData()
-			}
 		}).
 		AddItem(Raise.String(), "You hand raise", '3', func() {
-			v.infoText.SetText("")
-
-			err := v.Context.Action(Action{
-				Type: Raise,
-				Bet: v.Context.TurnBet * 2,
-			})
-			if err != nil {
-				v.infoText.Write([]byte(err.Error()))
-			} else {
-				v.infoText.Write([]byte(fmt.Sprintf("%sがRaiseを選択しました。\n", v.Context.getCurrentPlayer().Name)))
-				v.Context.NextPlayer()
-				v.DrawByCurrentData()
-			}
+			v.focusRaiseInput()
 		}).
 		AddItem(Check.String(), "You hand check", '4', func() {
-			v.infoText.SetText("")
-
-			err := v.Context.Action(Action{
+			v.doAction(Action{
 				Type: Check,
 			})
-			if err != nil {
-				v.infoText.Write([]byte(err.Error()))
+		}).
+		AddItem(AllIn.String(), "You hand all-in", '5', func() {
+			v.doAction(Action{
+				Type: AllIn,
+			})
+		}).
+		AddItem("Straddle", "Toggle straddling when in the straddle seat", 's', func() {
+			player := v.viewPlayer()
+			player.WantsStraddle = !player.WantsStraddle
+			if player.WantsStraddle {
+				v.WriteInfoText(i18n.T(msgWillStraddle, player.Name))
+			} else {
+				v.WriteInfoText(i18n.T(msgStopStraddle, player.Name))
+			}
+		}).
+		AddItem("Muck", "Toggle mucking losing hands at showdown", 'm', func() {
+			player := v.viewPlayer()
+			player.MucksLosing = !player.MucksLosing
+			if player.MucksLosing {
+				v.WriteInfoText(i18n.T(msgWillMuck, player.Name))
 			} else {
-				v.infoText.Write([]byte(fmt.Sprintf("%sがCheckを選択しました。\n", v.Context.getCurrentPlayer().Name)))
-				v.Context.NextPlayer()
-				v.DrawByCurrentData()
+				v.WriteInfoText(i18n.T(msgWillShowHands, player.Name))
 			}
 		}).
+		AddItem("Show Cards", "Show your cards after everyone folded to you", 'c', func() {
+			if err := v.Context.ShowCards(v.viewPlayer()); err != nil {
+				v.WriteInfoText(err.Error())
+			}
+		}).
+		AddItem("Rabbit Hunt", "Show the cards that would have come after everyone folded", 'h', func() {
+			if err := v.Context.RabbitHunt(); err != nil {
+				v.WriteInfoText(err.Error())
+			}
+		}).
+		AddItem("Next Hand", "Deal the next hand", 'n', func() {
+			v.infoText.SetText("")
+			if err := v.Context.NextHand(); err != nil {
+				v.WriteInfoText(err.Error())
+			}
+			v.DrawByCurrentData()
+		}).
 		AddItem("Quit", "Press to exit this game", 'q', func() {
 			v.App.Stop()
 		})
 
-	// エネミー
-	enemy := v.Context.Players[1]
-	enemyTableText := tview.NewTextView().SetText("Enemy's Table").SetTextAlign(tview.AlignCenter)
-
-	v.enemyCardTable = v.createCardTable([]string{"？", "？"})
+	if v.Context.MaxRuns > 1 {
+		v.addRunItItem()
+	}
+	if v.Context.CashGame != nil {
+		v.addCashGameItems()
+	}
 
-	v.enemyMoneyText = tview.NewTextView().
-		SetText(enemy.GetMoneyString()).
-		SetTextColor(tcell.ColorWhite).
-		SetChangedFunc(func() {
-			v.App.Draw()
+	// レイズ額の入力
+	v.raiseInput = tview.NewInputField().
+		SetLabel(i18n.T(msgRaiseToLabel)).
+		SetAcceptanceFunc(tview.InputFieldInteger).
+		SetDoneFunc(func(key tcell.Key) {
+			if key == tcell.KeyEnter {
+				raiseBet, err := strconv.Atoi(v.raiseInput.GetText())
+				if err != nil {
+					raiseBet = v.Context.LegalActions(v.Context.getCurrentPlayer()).MinRaiseTo
+				}
+				v.doAction(Action{
+					Type: Raise,
+					Bet:  raiseBet,
+				})
+			}
+			v.raiseInput.SetText("")
+			v.App.SetFocus(v.playerActions)
 		})
-	v.enemyBetText = tview.NewTextView().
-		SetText(enemy.GetBetString()).
-		SetTextColor(tcell.ColorWhite).
-		SetChangedFunc(func() {
-			v.App.Draw()
+
+	// 残りのボードを配る回数の入力
+	v.runItInput = tview.NewInputField().
+		SetLabel(i18n.T(msgRunItLabel)).
+		SetAcceptanceFunc(tview.InputFieldInteger).
+		SetDoneFunc(func(key tcell.Key) {
+			runs, err := strconv.Atoi(v.runItInput.GetText())
+			if err != nil {
+				runs = 1
+			}
+			v.runItInput.SetText("")
+			v.App.SetFocus(v.playerActions)
+			if key == tcell.KeyEnter {
+				v.chooseRunItTimes(runs)
+			}
 		})
 
+	// テーブル全体の座席
+	v.seatTable = tview.NewTable().SetBorders(true)
+	v.seatTable.SetTitle("Seats").SetBorder(true).SetTitleColor(tcell.ColorGreen)
+
 	playerMainFlex := tview.NewFlex().SetDirection(tview.FlexRow)
-	enemyMainFlex := tview.NewFlex().SetDirection(tview.FlexRow)
 	playerMainFlex.SetBorder(true)
-	enemyMainFlex.SetBorder(true)
 
-	rootFlex := tview.NewFlex().
+	v.rootFlex = tview.NewFlex().
 		AddItem(playerMainFlex.
-			AddItem(playerTableText, 5, 1, false).
+			AddItem(v.playerTableText, 5, 1, false).
 			AddItem(tview.NewTextView().SetText("Hand").SetTextColor(tcell.ColorGreen), 2, 1, false).
 			AddItem(v.playerCardTable, 5, 1, false).
 			AddItem(tview.NewTextView().SetText("Total Money").SetTextColor(tcell.ColorYellow), 2, 1, false).
@@ -170,72 +257,406 @@ func (v *Viewer) DrawInit() error {
 			AddItem(tview.NewTextView().SetText("Bet").SetTextColor(tcell.ColorYellow), 2, 1, false).
 			AddItem(v.playerBetText, 3, 1, false).
 			AddItem(tview.NewTextView().SetText("Action").SetTextColor(tcell.ColorRed), 2, 1, false).
-			AddItem(v.playerActions, 20, 1, true).
+			AddItem(v.playerActions, v.playerActions.GetItemCount()*2, 1, true).
+			AddItem(v.raiseInput, 1, 1, false).
+			AddItem(v.runItInput, 1, 1, false).
 			AddItem(tview.NewBox(), 0, 1, false), 0, 1, true).
 		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
 			AddItem(v.turnText, 3, 1, false).
 			AddItem(v.potText, 3, 1, false).
-			AddItem(tview.NewTextView().SetText("Flop").SetTextColor(tcell.ColorGreen).SetTextAlign(tview.AlignCenter), 10, 1, false).
-			AddItem(v.flopCardTable, 5, 1, false).
+			AddItem(v.levelText, 3, 1, false).
+			AddItem(v.flopText, 8, 1, false).
+			AddItem(v.flopCardTable, 7, 1, false).
 			AddItem(v.infoText, 0, 1, false), 0, 2, false).
-		AddItem(enemyMainFlex.
-			AddItem(enemyTableText, 5, 1, false).
-			AddItem(tview.NewTextView().SetText("Hand").SetTextColor(tcell.ColorGreen), 2, 1, false).
-			AddItem(v.enemyCardTable, 5, 1, false).
-			AddItem(tview.NewTextView().SetText("Total Money").SetTextColor(tcell.ColorYellow), 2, 1, false).
-			AddItem(v.enemyMoneyText, 3, 1, false).
-			AddItem(tview.NewTextView().SetText("Bet").SetTextColor(tcell.ColorYellow), 2, 1, false).
-			AddItem(v.enemyBetText, 3, 1, false).
-			AddItem(tview.NewBox(), 0, 1, false), 0, 1, false)
+		AddItem(v.seatTable, 0, 2, false)
 	v.DrawByCurrentData()
-	if err := v.App.SetRoot(rootFlex, true).Run(); err != nil {
+	return nil
+}
+
+// addRunItItem は全員がオールインしたときに、残りのボードを配る回数を選ぶ項目を「Next Hand」の前に追加する
+func (v *Viewer) addRunItItem() {
+	v.playerActions.InsertItem(v.playerActions.GetItemCount()-2, "Run It", "Choose how many times to run the board when everyone is all-in", 't', func() {
+		v.focusRunItInput()
+	})
+}
+
+// runItChooser は残りのボードを配る回数をまだ選んでいない人間のプレイヤーを返す。いなければnilを返す
+func (v *Viewer) runItChooser() *Player {
+	for _, player := range v.Context.getNotFoldPlayers() {
+		if !player.IsBot() && v.Context.IsChoosingRunItTimes(player) {
+			return player
+		}
+	}
+	return nil
+}
+
+// focusRunItInput は残りのボードを配る回数の入力欄にフォーカスを移す
+// 入力欄には選べる回数の範囲を表示し、空欄のまま確定すると1回だけ配る
+func (v *Viewer) focusRunItInput() {
+	player := v.runItChooser()
+	if player == nil {
+		v.WriteInfoText(ErrNotChoosingRuns.Error())
+		return
+	}
+	v.runItInput.SetLabel(player.Name + " " + i18n.T(msgRunItLabel))
+	v.runItInput.SetPlaceholder(fmt.Sprintf("1 - %d", v.Context.maxRunItTimes()))
+	v.App.SetFocus(v.runItInput)
+}
+
+// chooseRunItTimes は入力された回数を、まだ選んでいない人間のプレイヤーの希望として伝える
+// 他にも選んでいない人間のプレイヤーがいれば、続けて入力欄にフォーカスを移す
+func (v *Viewer) chooseRunItTimes(runs int) {
+	player := v.runItChooser()
+	if player == nil {
+		return
+	}
+	if err := v.Context.ChooseRunItTimes(player, runs); err != nil {
+		v.WriteInfoText(err.Error())
+		return
+	}
+	if runs > 1 {
+		v.WriteInfoText(i18n.T(msgRunItTimes, player.Name, runs))
+	} else {
+		v.WriteInfoText(i18n.T(msgRunItOnce, player.Name))
+	}
+	v.DrawByCurrentData()
+	if v.runItChooser() != nil {
+		v.focusRunItInput()
+	}
+}
+
+// addCashGameItems はキャッシュゲームでハンドの間に行う操作を「Next Hand」の前に追加する
+func (v *Viewer) addCashGameItems() {
+	index := v.playerActions.GetItemCount() - 2
+	v.playerActions.
+		InsertItem(index, "Rebuy / Top Up", "Buy chips up to the max buy-in", 'r', func() {
+			if err := v.Context.TopUpToMax(v.viewPlayer()); err != nil {
+				v.WriteInfoText(err.Error())
+			}
+			v.DrawByCurrentData()
+		}).
+		InsertItem(index+1, "Sit Out / In", "Toggle being dealt out from the next hand", 'o', func() {
+			player := v.viewPlayer()
+			var err error
+			if player.IsSittingOut {
+				err = v.Context.SitIn(player)
+			} else {
+				err = v.Context.SitOut(player)
+			}
+			if err != nil {
+				v.WriteInfoText(err.Error())
+			}
+			v.DrawByCurrentData()
+		}).
+		InsertItem(index+2, "Leave / Join", "Leave the table or join again with the max buy-in", 'l', func() {
+			player := v.viewPlayer()
+			var err error
+			if player.HasLeft {
+				_, err = v.Context.Join(Seat{Name: player.Name, Money: v.Context.CashGame.MaxBuyIn, Controller: player.Controller})
+			} else {
+				err = v.Context.Leave(player)
+			}
+			if err != nil {
+				v.WriteInfoText(err.Error())
+			}
+			v.DrawByCurrentData()
+		})
+}
+
+func (v *Viewer) Run() error {
+	// ブラインドレベルの残り時間を1秒ごとに更新する
+	if v.Context.Schedule != nil {
+		go func() {
+			for range time.Tick(time.Second) {
+				v.App.QueueUpdateDraw(v.drawLevel)
+			}
+		}()
+	}
+	if err := v.App.SetRoot(v.rootFlex, true).Run(); err != nil {
 		return err
 	}
 	return nil
 }
 
+func (v *Viewer) doAction(a Action) {
+	cp := v.Context.getCurrentPlayer()
+	if v.Context.IsHandFinished || cp.IsBot() {
+		return
+	}
+	v.infoText.SetText("")
+	if !v.Context.LegalActions(cp).Can(a.Type) {
+		v.WriteInfoText(i18n.T(msgActionNotAllowed, a.Type))
+		return
+	}
+
+	err := v.Context.Action(a)
+	if err != nil {
+		v.infoText.Write([]byte(err.Error()))
+		return
+	}
+	v.Context.NextPlayer()
+	v.DrawByCurrentData()
+}
+
+// focusRaiseInput はレイズ額の入力欄にフォーカスを移す
+// 入力欄には指定できるレイズ額の範囲を表示し、空欄のまま確定すると最低レイズ額でレイズする
+func (v *Viewer) focusRaiseInput() {
+	cp := v.Context.getCurrentPlayer()
+	if v.Context.IsHandFinished || cp.IsBot() {
+		return
+	}
+	legal := v.Context.LegalActions(cp)
+	if !legal.Can(Raise) {
+		v.WriteInfoText(i18n.T(msgActionNotAllowed, Raise))
+		return
+	}
+	v.raiseInput.SetPlaceholder(fmt.Sprintf("%d - %d", legal.MinRaiseTo, legal.MaxRaiseTo))
+	v.App.SetFocus(v.raiseInput)
+}
+
+// viewPlayer は左側のパネルに表示するプレイヤーを返す
+// 人間の手番ならそのプレイヤーを、Botの手番なら最初の人間のプレイヤーを表示する
+func (v *Viewer) viewPlayer() *Player {
+	cp := v.Context.getCurrentPlayer()
+	if !cp.IsBot() {
+		return cp
+	}
+	for _, player := range v.Context.Players {
+		if !player.IsBot() {
+			return player
+		}
+	}
+	return v.Context.Players[0]
+}
+
+// StartHand は新しいハンドの開始時に前のハンドの表示を片付ける
+func (v *Viewer) StartHand() {
+	v.openedPlayers = make(map[*Player]bool, len(v.Context.Players))
+}
+
+// OnEvent はゲームのイベントを情報欄に表示し、画面を最新の状態に描き直す
+func (v *Viewer) OnEvent(e Event) {
+	switch e := e.(type) {
+	case *HandStarted:
+		v.StartHand()
+	case *HoleCardsDealt:
+		v.DrawByCurrentData()
+		return
+	case *BlindPosted:
+		// ストラドル以外の強制ベットは座席表の表示だけで伝える
+		if e.Type != StraddlePost {
+			v.DrawByCurrentData()
+			return
+		}
+	case *ShowdownRevealed:
+		if player := v.Context.Players[e.Seat]; player.IsBot() {
+			v.OpenPlayerCards(player)
+		}
+	case *CardsShown:
+		v.OpenPlayerCards(v.Context.Players[e.Seat])
+	case *HandEnded:
+		// 飛んだプレイヤーはHandEndedより前に記録されるため、ここで順位も反映される
+		v.equities = v.Context.FutureGameEquities()
+	}
+	v.WriteInfoText(e.String())
+	if e, ok := e.(*HandEnded); ok && !e.IsGameOver {
+		v.WriteInfoText(i18n.T(msgNextHandPrompt))
+	}
+	v.DrawByCurrentData()
+	if _, ok := e.(*RunItOffered); ok && v.runItChooser() != nil {
+		v.focusRunItInput()
+	}
+}
+
 func (v *Viewer) DrawByCurrentData() {
+	v.turnText.SetTitle(fmt.Sprintf("Hand #%d - Turn", v.Context.HandCount))
+	v.turnText.SetText(v.Context.getCurrentPlayer().Name)
 	v.potText.SetText(v.Context.GetPotString())
-	v.playerMoneyText.SetText(v.Context.Players[0].GetMoneyString())
-	v.playerBetText.SetText(v.Context.Players[0].GetBetString())
-	v.enemyMoneyText.SetText(v.Context.Players[1].GetMoneyString())
-	v.enemyBetText.SetText(v.Context.Players[1].GetBetString())
-	for i, flopStr := range v.Context.GetFlopStrings() {
-		s := strings.Split(flopStr, " ")[0]
-		v.flopCardTable.
-			SetCell(0, i, tview.NewTableCell(flopStr).
-				SetTextColor(v.getCardTableCellColor(s)).
+
+	player := v.viewPlayer()
+	v.playerTableText.SetText(player.Name + "'s Table")
+	v.setCardCells(v.playerCardTable, player.GetHandStrings())
+	v.playerMoneyText.SetText(player.GetMoneyString())
+	v.playerBetText.SetText(player.GetBetString())
+
+	v.flopText.SetText(v.Context.Street.String())
+	v.setCardRows(v.flopCardTable, v.Context.GetBoardStrings())
+	v.setHuntedCells(v.flopCardTable, len(v.Context.Flop), v.Context.Hunted)
+	v.drawActions()
+	v.drawSeatTable()
+	v.drawLevel()
+}
+
+// actionItemTypes はアクションの一覧の先頭から並ぶ項目のアクション
+var actionItemTypes = []ActionType{Fold, Call, Raise, Check, AllIn}
+
+// drawActions は人間の手番で選択できるアクションに、コールやレイズの額を添えて表示する
+// 選択できないアクションは灰色で表示する
+func (v *Viewer) drawActions() {
+	cp := v.Context.getCurrentPlayer()
+	legal := LegalActions{}
+	if !cp.IsBot() {
+		legal = v.Context.LegalActions(cp)
+	}
+	for i, actionType := range actionItemTypes {
+		text := actionType.String()
+		switch actionType {
+		case Call:
+			if legal.CallAmount > 0 {
+				text += fmt.Sprintf(" ＄%d", legal.CallAmount)
+			}
+		case Raise:
+			if legal.MaxRaiseTo > 0 {
+				text += fmt.Sprintf(" ＄%d - ＄%d", legal.MinRaiseTo, legal.MaxRaiseTo)
+			}
+		case AllIn:
+			if legal.Can(AllIn) {
+				text += fmt.Sprintf(" ＄%d", cp.CurrentBet+cp.Money)
+			}
+		}
+		if !legal.Can(actionType) {
+			text = "[gray]" + text
+		}
+		_, secondary := v.playerActions.GetItemText(i)
+		v.playerActions.SetItemText(i, text, secondary)
+	}
+}
+
+// drawLevel は現在のブラインドと、次のレベルまでの残り時間とハンド数を表示する
+func (v *Viewer) drawLevel() {
+	level, ok := v.Context.CurrentBlindLevel()
+	if !ok {
+		v.levelText.SetText(BlindLevel{
+			SmallBlind: v.Context.SmollBlind,
+			BigBlind:   v.Context.BigBlind,
+			Ante:       v.Context.Ante,
+		}.String())
+		return
+	}
+
+	text := i18n.T(msgLevelText, v.Context.Level+1, level)
+	remainingTime, remainingHands := v.Context.LevelRemaining(time.Now())
+	if remainingTime >= 0 {
+		seconds := int(remainingTime.Seconds())
+		text += fmt.Sprintf("  %02d:%02d", seconds/60, seconds%60)
+	}
+	if remainingHands >= 0 {
+		text += "  " + i18n.T(msgHandsLeft, remainingHands)
+	}
+	v.levelText.SetText(text)
+}
+
+func (v *Viewer) drawSeatTable() {
+	v.seatTable.Clear()
+	headers := []string{"Seat", "Name", "Pos", "Hand", "Money", "Bet", "Action", "Net"}
+	if v.equities != nil {
+		headers[len(headers)-1] = "ICM"
+	}
+	for i, header := range headers {
+		v.seatTable.SetCell(0, i, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetAlign(tview.AlignCenter).
+			SetSelectable(false))
+	}
+
+	for i, player := range v.Context.Players {
+		handText := "？ ？"
+		if v.openedPlayers[player] {
+			handText = strings.Join(player.GetHandStrings(), " / ")
+		}
+		actionText := ""
+		if player.CurrentAction.Type != 0 {
+			actionText = player.CurrentAction.Type.String()
+		}
+		positionText := player.Position.String()
+		if v.Context.IsStraddler(player) {
+			positionText += " STR"
+		}
+		if !player.isDealtIn() {
+			handText = ""
+			positionText = "-"
+			if player.HasLeft {
+				positionText = "Left"
+			} else if player.IsSittingOut {
+				positionText = "Out"
+			}
+		}
+		color := tcell.ColorWhite
+		if !player.isDealtIn() || player.CurrentAction.Type == Fold {
+			color = tcell.ColorGray
+		} else if i == v.Context.TurnIndex && !v.Context.IsHandFinished {
+			color = tcell.ColorOrange
+		}
+
+		cells := []string{
+			strconv.Itoa(i + 1),
+			player.Name,
+			positionText,
+			handText,
+			player.GetMoneyString(),
+			player.GetBetString(),
+			actionText,
+			player.GetNetResultString(),
+		}
+		if v.equities != nil {
+			cells[len(cells)-1] = fmt.Sprintf("＄%.2f", v.equities[i])
+		}
+		for j, cellText := range cells {
+			v.seatTable.SetCell(i+1, j, tview.NewTableCell(cellText).
+				SetTextColor(color).
 				SetAlign(tview.AlignCenter))
+		}
 	}
 }
 
 func (v *Viewer) createCardTable(cardStrings []string) *tview.Table {
 	cardTable := tview.NewTable().SetBorders(true)
-	for i, cardStr := range cardStrings {
-		s := strings.Split(cardStr, " ")[0]
-		cardTable.
-			SetCell(0, i, tview.NewTableCell(cardStr).
-				SetTextColor(v.getCardTableCellColor(s)).
-				SetAlign(tview.AlignCenter))
-	}
+	v.setCardCells(cardTable, cardStrings)
 	return cardTable
 }
 
+func (v *Viewer) setCardCells(cardTable *tview.Table, cardStrings []string) {
+	v.setCardRows(cardTable, [][]string{cardStrings})
+}
+
+// setCardRows はカードを1行ずつ表示する。ボードを複数回配った場合は全てのボードを並べる
+func (v *Viewer) setCardRows(cardTable *tview.Table, rows [][]string) {
+	cardTable.Clear()
+	for row, cardStrings := range rows {
+		for i, cardStr := range cardStrings {
+			s := strings.Split(cardStr, " ")[0]
+			cardTable.
+				SetCell(row, i, tview.NewTableCell(cardStr).
+					SetTextColor(v.getCardTableCellColor(s)).
+					SetAlign(tview.AlignCenter))
+		}
+	}
+}
+
+// setHuntedCells はラビットハントしたカードを、ボードの続きに灰色で表示する
+func (v *Viewer) setHuntedCells(cardTable *tview.Table, from int, hunted []card.Card) {
+	for i, cardStr := range replayCardStrings(hunted) {
+		cardTable.SetCell(0, from+i, tview.NewTableCell(cardStr).
+			SetTextColor(tcell.ColorGray).
+			SetAlign(tview.AlignCenter))
+	}
+}
+
 func (v *Viewer) WriteInfoText(text string) {
 	v.infoText.Write([]byte(text + "\n"))
 }
 
-func (v *Viewer) OpenEnemyCards(cardStrings []string) {
-	for i, cardStr := range cardStrings {
-		s := strings.Split(cardStr, " ")[0]
-		v.enemyCardTable.
-			SetCell(0, i, tview.NewTableCell(cardStr).
-				SetTextColor(v.getCardTableCellColor(s)).
-				SetAlign(tview.AlignCenter))
-	}
+func (v *Viewer) OpenPlayerCards(player *Player) {
+	v.openedPlayers[player] = true
+	v.drawSeatTable()
 }
 
 func (v *Viewer) getCardTableCellColor(s string) tcell.Color {
+	return cardSuitColor(s)
+}
+
+// cardSuitColor はカードのマークの文字列から、カードを表示する色を返す
+func cardSuitColor(s string) tcell.Color {
 	switch s {
 	case card.Spade.String():
 		return tcell.ColorWhite